@@ -0,0 +1,734 @@
+package codeforces
+
+import (
+	"context"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// apiClient fetches data through the official JSON API
+	// of the website. See https://codeforces.com/apiHelp.
+	apiClient struct {
+		client *http.Client
+		host   string
+		key    string
+		secret string
+		// retryAfter is the time to wait before retrying,
+		// if the call limit of the API is exceeded. It is
+		// doubled on each retry.
+		retryAfter time.Duration
+		// now and nonce return the time and the random
+		// prefix used to sign requests.
+		now   func() time.Time
+		nonce func() string
+	}
+
+	apiParty struct {
+		Members []struct {
+			Handle string `json:"handle"`
+		} `json:"members"`
+		ParticipantType string `json:"participantType"`
+		TeamName        string `json:"teamName"`
+	}
+
+	apiProblem struct {
+		ContestID int      `json:"contestId"`
+		Index     string   `json:"index"`
+		Name      string   `json:"name"`
+		Rating    int      `json:"rating"`
+		Tags      []string `json:"tags"`
+	}
+
+	apiContest struct {
+		ID               int    `json:"id"`
+		Name             string `json:"name"`
+		DurationSeconds  int64  `json:"durationSeconds"`
+		StartTimeSeconds int64  `json:"startTimeSeconds"`
+		PreparedBy       string `json:"preparedBy"`
+	}
+
+	apiSubmission struct {
+		ID                  int64      `json:"id"`
+		ContestID           int        `json:"contestId"`
+		CreationTimeSeconds int64      `json:"creationTimeSeconds"`
+		Problem             apiProblem `json:"problem"`
+		Author              apiParty   `json:"author"`
+		ProgrammingLanguage string     `json:"programmingLanguage"`
+		Verdict             string     `json:"verdict"`
+		PassedTestCount     int        `json:"passedTestCount"`
+		TimeConsumedMillis  int64      `json:"timeConsumedMillis"`
+		MemoryConsumedBytes int64      `json:"memoryConsumedBytes"`
+	}
+
+	apiRanklistRow struct {
+		Party                 apiParty `json:"party"`
+		Rank                  int      `json:"rank"`
+		Points                float64  `json:"points"`
+		Penalty               int      `json:"penalty"`
+		SuccessfulHackCount   int      `json:"successfulHackCount"`
+		UnsuccessfulHackCount int      `json:"unsuccessfulHackCount"`
+		ProblemResults        []struct {
+			Points                    float64 `json:"points"`
+			RejectedAttemptCount      int     `json:"rejectedAttemptCount"`
+			BestSubmissionTimeSeconds int64   `json:"bestSubmissionTimeSeconds"`
+		} `json:"problemResults"`
+	}
+
+	apiUser struct {
+		Handle                  string `json:"handle"`
+		Rating                  int    `json:"rating"`
+		MaxRating               int    `json:"maxRating"`
+		Rank                    string `json:"rank"`
+		MaxRank                 string `json:"maxRank"`
+		Contribution            int    `json:"contribution"`
+		FriendOfCount           int    `json:"friendOfCount"`
+		Organization            string `json:"organization"`
+		Country                 string `json:"country"`
+		City                    string `json:"city"`
+		RegistrationTimeSeconds int64  `json:"registrationTimeSeconds"`
+		LastOnlineTimeSeconds   int64  `json:"lastOnlineTimeSeconds"`
+		TitlePhoto              string `json:"titlePhoto"`
+	}
+
+	apiStandings struct {
+		Problems []apiProblem     `json:"problems"`
+		Rows     []apiRanklistRow `json:"rows"`
+	}
+
+	apiProblemset struct {
+		Problems   []apiProblem `json:"problems"`
+		Statistics []struct {
+			ContestID   int    `json:"contestId"`
+			Index       string `json:"index"`
+			SolvedCount int    `json:"solvedCount"`
+		} `json:"problemStatistics"`
+	}
+)
+
+// apiMaxRetries is the number of times a call is
+// retried, if the call limit of the API is exceeded.
+const apiMaxRetries = 4
+
+// Verdicts (as shown on the website) of the verdict names
+// returned by the API. Only TESTING (and an empty verdict)
+// are judging; verdicts not listed are shown as returned.
+var apiVerdicts = map[string]string{
+	"OK":                        "Accepted",
+	"WRONG_ANSWER":              "Wrong answer on test %v",
+	"RUNTIME_ERROR":             "Runtime error on test %v",
+	"COMPILATION_ERROR":         "Compilation error",
+	"TIME_LIMIT_EXCEEDED":       "Time limit exceeded on test %v",
+	"MEMORY_LIMIT_EXCEEDED":     "Memory limit exceeded on test %v",
+	"IDLENESS_LIMIT_EXCEEDED":   "Idleness limit exceeded on test %v",
+	"CRASHED":                   "Denial of judgement",
+	"SKIPPED":                   "Skipped",
+	"CHALLENGED":                "Hacked",
+	"PARTIAL":                   "Partial result",
+	"PRESENTATION_ERROR":        "Presentation error on test %v",
+	"FAILED":                    "Judgement failed",
+	"REJECTED":                  "Rejected",
+	"SECURITY_VIOLATED":         "Security violated on test %v",
+	"INPUT_PREPARATION_CRASHED": "Input preparation crashed on test %v",
+	"TESTING":                   "Running on test %v",
+	"":                          "In queue",
+}
+
+// Participation types of the participant types returned by the API.
+var apiParticipants = map[string]int{
+	"CONTESTANT":         ParticipantContestant,
+	"PRACTICE":           ParticipantPractice,
+	"VIRTUAL":            ParticipantVirtual,
+	"OUT_OF_COMPETITION": ParticipantOutOfCompetition,
+}
+
+func newAPIClient(host, key, secret string) *apiClient {
+	return &apiClient{
+		client:     &http.Client{Timeout: time.Minute},
+		host:       host,
+		key:        key,
+		secret:     secret,
+		retryAfter: time.Second * 2,
+		now:        time.Now,
+		nonce: func() string {
+			return fmt.Sprintf("%06d", rand.Intn(1e6))
+		},
+	}
+}
+
+// sign adds the authorization parameters to params, as
+// documented in https://codeforces.com/apiHelp (section
+// 'Authorization').
+func (a *apiClient) sign(method string, params url.Values) {
+	params.Del("apiSig")
+	params.Set("apiKey", a.key)
+	params.Set("time", strconv.FormatInt(a.now().Unix(), 10))
+
+	// Parameters are sorted by key, and then by value.
+	type pair struct{ key, val string }
+	var pairs []pair
+	for key, vals := range params {
+		for _, val := range vals {
+			pairs = append(pairs, pair{key, val})
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].key != pairs[j].key {
+			return pairs[i].key < pairs[j].key
+		}
+		return pairs[i].val < pairs[j].val
+	})
+
+	query := make([]string, len(pairs))
+	for i, p := range pairs {
+		query[i] = p.key + "=" + p.val
+	}
+
+	rnd := a.nonce()
+	hash := sha512.Sum512([]byte(fmt.Sprintf("%v/%v?%v#%v",
+		rnd, method, strings.Join(query, "&"), a.secret)))
+	params.Set("apiSig", rnd+hex.EncodeToString(hash[:]))
+}
+
+// call calls the API method with the given parameters,
+// and decodes the returned result into result. If the
+// call limit is exceeded, the call is retried (at most
+// apiMaxRetries times), waiting longer each time.
+func (a *apiClient) call(ctx context.Context, method string, params url.Values, result interface{}) error {
+	if params == nil {
+		params = url.Values{}
+	}
+
+	wait := a.retryAfter
+	for retry := 0; ; retry++ {
+		limited, err := a.do(ctx, method, params, result)
+		if !limited || retry == apiMaxRetries {
+			return err
+		}
+
+		select {
+		case <-time.After(wait):
+			wait *= 2
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// do calls the API method once, as call does. Reports
+// whether the call failed as the call limit is exceeded.
+func (a *apiClient) do(ctx context.Context, method string, params url.Values, result interface{}) (bool, error) {
+	if a.key != "" && a.secret != "" {
+		a.sign(method, params)
+	}
+
+	link := fmt.Sprintf("%v/api/%v?%v", a.host, method, params.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		Status  string          `json:"status"`
+		Comment string          `json:"comment"`
+		Result  json.RawMessage `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		// Not a response of the API (like the page
+		// shown while the website is unavailable).
+		return false, &SiteError{Err: ErrAPIFailed, Msg: resp.Status}
+	}
+
+	if data.Status != "OK" {
+		limited := strings.Contains(strings.ToLower(data.Comment), "call limit exceeded")
+		return limited, newAPIError(data.Comment)
+	}
+
+	return false, json.Unmarshal(data.Result, result)
+}
+
+// newAPIError returns the library error, corresponding
+// to the comment returned by a failed API call.
+func newAPIError(comment string) error {
+	lcomment := strings.ToLower(comment)
+	switch {
+	case strings.Contains(lcomment, "contest with id") && strings.Contains(lcomment, "not found"):
+		return &SiteError{Err: ErrContestNotFound, Msg: comment}
+	case strings.Contains(lcomment, "user with handle") && strings.Contains(lcomment, "not found"):
+		return &SiteError{Err: ErrUserNotFound, Msg: comment}
+	case strings.Contains(lcomment, "not allowed"), strings.Contains(lcomment, "incorrect signature"),
+		strings.Contains(lcomment, "incorrect api key"):
+		return &SiteError{Err: ErrAccessDenied, Msg: comment}
+	}
+	return &SiteError{Err: ErrAPIFailed, Msg: comment}
+}
+
+// apiArgs returns the specifier of the contest (and problem) in
+// the API. The group is taken from arg, as it isn't returned.
+func apiArgs(contestID int, index string, arg Args) Args {
+	if arg.Class == ClassGroup {
+		return Args{strconv.Itoa(contestID), strings.ToLower(index), ClassGroup, arg.Group}
+	}
+	res, _ := Parse(fmt.Sprintf("%v%v", contestID, index))
+	return res
+}
+
+func (c apiContest) toContest(arg Args) Contest {
+	contest := Contest{
+		Name:      c.Name,
+		StartTime: time.Unix(c.StartTimeSeconds, 0).UTC(),
+		Duration:  time.Duration(c.DurationSeconds) * time.Second,
+		// Not provided by the API.
+		RegCount:  RegistrationNotExists,
+		RegStatus: RegistrationNotExists,
+		Arg:       apiArgs(c.ID, "", arg),
+	}
+	if c.PreparedBy != "" {
+		contest.Writers = []string{c.PreparedBy}
+	}
+	return contest
+}
+
+func (s apiSubmission) toSubmission(arg Args) Submission {
+	sub := Submission{
+		ID:           strconv.FormatInt(s.ID, 10),
+		When:         time.Unix(s.CreationTimeSeconds, 0).UTC(),
+		Who:          s.Author.name(),
+		Problem:      fmt.Sprintf("%v - %v", s.Problem.Index, s.Problem.Name),
+		Language:     s.ProgrammingLanguage,
+		Time:         fmt.Sprintf("%v ms", s.TimeConsumedMillis),
+		Memory:       fmt.Sprintf("%v KB", s.MemoryConsumedBytes>>10),
+		TimeDuration: time.Duration(s.TimeConsumedMillis) * time.Millisecond,
+		MemoryBytes:  s.MemoryConsumedBytes,
+		Participant:  apiParticipants[s.Author.ParticipantType],
+		Arg:          apiArgs(s.ContestID, s.Problem.Index, arg),
+	}
+
+	verdict, ok := apiVerdicts[s.Verdict]
+	if !ok {
+		verdict = s.Verdict
+	}
+	if strings.Contains(verdict, "%v") {
+		verdict = fmt.Sprintf(verdict, s.PassedTestCount+1)
+	}
+	sub.Verdict = verdict
+
+	sub.VerdictStatus = verdictNames[s.Verdict]
+	sub.IsJudging = s.Verdict == "TESTING" || s.Verdict == ""
+	return sub
+}
+
+// name returns the team name (or handles, comma
+// separated, if not a team) of the party.
+func (p apiParty) name() string {
+	if p.TeamName != "" {
+		return p.TeamName
+	}
+	var handles []string
+	for _, member := range p.Members {
+		handles = append(handles, member.Handle)
+	}
+	return strings.Join(handles, ", ")
+}
+
+func (r apiRanklistRow) toStandingsRow(problems []apiProblem) StandingsRow {
+	row := StandingsRow{
+		Rank:              r.Rank,
+		Team:              r.Party.TeamName,
+		Unofficial:        r.Party.ParticipantType != "CONTESTANT",
+		Points:            r.Points,
+		Penalty:           r.Penalty,
+		SuccessfulHacks:   r.SuccessfulHackCount,
+		UnsuccessfulHacks: r.UnsuccessfulHackCount,
+	}
+	for _, member := range r.Party.Members {
+		row.Members = append(row.Members, member.Handle)
+	}
+	if row.Unofficial {
+		// Ranks are shown only for official participants.
+		row.Rank = 0
+	}
+
+	for i, res := range r.ProblemResults {
+		result := ProblemResult{
+			Points:   res.Points,
+			Accepted: res.Points > 0,
+			Rejected: res.RejectedAttemptCount,
+		}
+		if i < len(problems) {
+			result.Problem = strings.ToLower(problems[i].Index)
+		}
+		if result.Accepted {
+			result.AcceptedAt = time.Duration(res.BestSubmissionTimeSeconds) * time.Second
+		}
+		row.Results = append(row.Results, result)
+	}
+	return row
+}
+
+func (u apiUser) toUser() User {
+	return User{
+		Handle:       u.Handle,
+		Rating:       u.Rating,
+		MaxRating:    u.MaxRating,
+		Rank:         u.Rank,
+		MaxRank:      u.MaxRank,
+		Contribution: u.Contribution,
+		Friends:      u.FriendOfCount,
+		Organization: u.Organization,
+		Country:      u.Country,
+		City:         u.City,
+		Registered:   time.Unix(u.RegistrationTimeSeconds, 0).UTC(),
+		LastVisit:    time.Unix(u.LastOnlineTimeSeconds, 0).UTC(),
+		Avatar:       absURL(u.TitlePhoto),
+	}
+}
+
+// contests returns contests of the class (and group) of arg,
+// or only the contest of arg, if specified.
+func (a *apiClient) contests(ctx context.Context, arg Args) ([]Contest, error) {
+	params := url.Values{}
+	switch arg.Class {
+	case ClassGym:
+		params.Set("gym", "true")
+	case ClassGroup:
+		if arg.Group == "" {
+			return nil, ErrInvalidSpecifier
+		}
+		params.Set("groupCode", arg.Group)
+	case ClassContest:
+	default:
+		return nil, ErrInvalidSpecifier
+	}
+
+	var result []apiContest
+	if err := a.call(ctx, "contest.list", params, &result); err != nil {
+		return nil, err
+	}
+
+	contests := make([]Contest, 0)
+	for _, c := range result {
+		if arg.Contest != "" && arg.Contest != strconv.Itoa(c.ID) {
+			continue
+		}
+		contests = append(contests, c.toContest(arg))
+	}
+
+	if arg.Contest != "" && len(contests) == 0 {
+		return nil, ErrContestNotFound
+	}
+	return contests, nil
+}
+
+// submissions returns submissions, from the from-th (1-based)
+// latest one, of the user (or all users, if handle is empty)
+// in the contest of arg, or of the user in all contests, if
+// contest is not specified. If neither is specified, returns
+// the latest submissions in the problemset.
+func (a *apiClient) submissions(ctx context.Context, arg Args, handle string, from, count int) ([]Submission, error) {
+	params := url.Values{}
+	method := "contest.status"
+	switch {
+	case arg.Contest != "":
+		params.Set("contestId", arg.Contest)
+		if handle != "" {
+			params.Set("handle", handle)
+		}
+	case handle != "":
+		method = "user.status"
+		params.Set("handle", handle)
+	default:
+		method = "problemset.recentStatus"
+	}
+
+	if method == "problemset.recentStatus" {
+		// Only the latest (at most 1000) submissions are available.
+		if from+count-1 > 1000 {
+			return make([]Submission, 0), nil
+		}
+		params.Set("count", strconv.Itoa(from+count-1))
+	} else {
+		params.Set("from", strconv.Itoa(from))
+		params.Set("count", strconv.Itoa(count))
+	}
+
+	var result []apiSubmission
+	if err := a.call(ctx, method, params, &result); err != nil {
+		return nil, err
+	}
+
+	if method == "problemset.recentStatus" {
+		if len(result) < from {
+			result = nil
+		} else {
+			result = result[from-1:]
+		}
+	}
+
+	submissions := make([]Submission, 0)
+	for _, s := range result {
+		submissions = append(submissions, s.toSubmission(arg))
+	}
+	return submissions, nil
+}
+
+// standings returns rows of the standings
+// from the from-th (1-based) row onward.
+func (a *apiClient) standings(ctx context.Context, arg Args, opts StandingsOptions, from, count int) ([]StandingsRow, error) {
+	if arg.Contest == "" {
+		return nil, ErrInvalidSpecifier
+	}
+
+	params := url.Values{}
+	params.Set("contestId", arg.Contest)
+	params.Set("from", strconv.Itoa(from))
+	params.Set("count", strconv.Itoa(count))
+	params.Set("showUnofficial", strconv.FormatBool(opts.Unofficial))
+	if opts.Room != "" {
+		params.Set("room", opts.Room)
+	}
+
+	var result apiStandings
+	if err := a.call(ctx, "contest.standings", params, &result); err != nil {
+		return nil, err
+	}
+
+	rows := make([]StandingsRow, 0)
+	for _, r := range result.Rows {
+		rows = append(rows, r.toStandingsRow(result.Problems))
+	}
+	return rows, nil
+}
+
+// problems returns the problems (with tags and difficulty
+// rating) of the contest of arg. The call is not retried.
+func (a *apiClient) problems(ctx context.Context, arg Args) ([]apiProblem, error) {
+	if arg.Contest == "" {
+		return nil, ErrInvalidSpecifier
+	}
+
+	// Only the problems are of use.
+	params := url.Values{}
+	params.Set("contestId", arg.Contest)
+	params.Set("from", "1")
+	params.Set("count", "1")
+
+	var result apiStandings
+	if _, err := a.do(ctx, "contest.standings", params, &result); err != nil {
+		return nil, err
+	}
+	return result.Problems, nil
+}
+
+// user returns the profile of the user.
+func (a *apiClient) user(ctx context.Context, handle string) (User, error) {
+	params := url.Values{}
+	params.Set("handles", handle)
+
+	var result []apiUser
+	if err := a.call(ctx, "user.info", params, &result); err != nil {
+		return User{}, err
+	}
+	if len(result) == 0 {
+		return User{}, ErrUserNotFound
+	}
+	return result[0].toUser(), nil
+}
+
+// problemset returns problems in the problemset matching the
+// filter. Solve status is not returned, and is thus not matched.
+func (a *apiClient) problemset(ctx context.Context, filter ProblemsetFilter) ([]Problem, error) {
+	params := url.Values{}
+	if len(filter.Tags) != 0 {
+		params.Set("tags", strings.Join(filter.Tags, ";"))
+	}
+
+	var result apiProblemset
+	if err := a.call(ctx, "problemset.problems", params, &result); err != nil {
+		return nil, err
+	}
+
+	solved := make(map[string]int)
+	for _, stat := range result.Statistics {
+		solved[fmt.Sprintf("%v%v", stat.ContestID, stat.Index)] = stat.SolvedCount
+	}
+
+	problems := make([]Problem, 0)
+	for _, p := range result.Problems {
+		problem := Problem{
+			Name:        p.Name,
+			Tags:        p.Tags,
+			Rating:      p.Rating,
+			SolveCount:  solved[fmt.Sprintf("%v%v", p.ContestID, p.Index)],
+			SolveStatus: SolveNotAttempted,
+			Arg:         apiArgs(p.ContestID, p.Index, Args{Class: ClassContest}),
+		}
+		if filter.match(problem) {
+			problems = append(problems, problem)
+		}
+	}
+	return problems, nil
+}
+
+// pageContests sends contests in pages (of 100 rows), as GetContests.
+func (a *apiClient) pageContests(ctx context.Context, arg Args, pageCount uint) (<-chan []Contest, error) {
+	const size = 100
+	contests, err := a.contests(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	chanContests := make(chan []Contest)
+	go func() {
+		defer close(chanContests)
+
+		for ; pageCount > 0 && len(contests) > 0; pageCount-- {
+			n := size
+			if len(contests) < n {
+				n = len(contests)
+			}
+
+			select {
+			case chanContests <- contests[:n]:
+			case <-ctx.Done():
+				return
+			}
+			contests = contests[n:]
+		}
+	}()
+	return chanContests, nil
+}
+
+// pageProblemset sends problems in pages (of 100 rows), as GetProblemset.
+func (a *apiClient) pageProblemset(ctx context.Context, filter ProblemsetFilter, pageCount uint) (<-chan []Problem, error) {
+	const size = 100
+	problems, err := a.problemset(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	chanProblems := make(chan []Problem)
+	go func() {
+		defer close(chanProblems)
+
+		for ; pageCount > 0 && len(problems) > 0; pageCount-- {
+			n := size
+			if len(problems) < n {
+				n = len(problems)
+			}
+
+			select {
+			case chanProblems <- problems[:n]:
+			case <-ctx.Done():
+				return
+			}
+			problems = problems[n:]
+		}
+	}()
+	return chanProblems, nil
+}
+
+// pageSubmissions sends submissions in pages (of 50 rows), with
+// filters applied, as GetSubmissions. If handle is empty, sends
+// submissions of all users, as GetStatus.
+func (a *apiClient) pageSubmissions(ctx context.Context, arg Args, handle string,
+	pageCount uint, opts SubmissionsOptions) (<-chan []Submission, error) {
+
+	const size = 50
+	submissions, err := a.submissions(ctx, arg, handle, 1, size)
+	if err != nil {
+		return nil, err
+	}
+
+	chanSubmissions := make(chan []Submission)
+	go func() {
+		defer close(chanSubmissions)
+
+		for page := uint(1); page <= pageCount; {
+			// Participation types are returned by the API;
+			// the period of the contest isn't needed.
+			filtered := opts.filter(submissions, arg, time.Time{}, time.Time{})
+			select {
+			case chanSubmissions <- filtered:
+			case <-ctx.Done():
+				return
+			}
+
+			if pageCount == 1 {
+				// Keep polling till all verdicts are declared.
+				isJudging := false
+				for _, sub := range filtered {
+					isJudging = isJudging || sub.IsJudging
+				}
+				if !isJudging {
+					return
+				}
+
+				select {
+				case <-time.After(time.Millisecond * 1500):
+				case <-ctx.Done():
+					return
+				}
+			} else {
+				more := len(submissions) == size && (opts.Since.IsZero() ||
+					!submissions[len(submissions)-1].When.Before(opts.Since))
+				if !more {
+					// All pages (of use) sent.
+					return
+				}
+				page++
+			}
+
+			if submissions, err = a.submissions(ctx, arg, handle, int(page-1)*size+1, size); err != nil {
+				return
+			}
+		}
+	}()
+	return chanSubmissions, nil
+}
+
+// pageStandings sends standings in pages (of 200 rows), as GetStandings.
+func (a *apiClient) pageStandings(ctx context.Context, arg Args, pageCount uint, opts StandingsOptions) (<-chan []StandingsRow, error) {
+	const size = 200
+	rows, err := a.standings(ctx, arg, opts, 1, size)
+	if err != nil {
+		return nil, err
+	}
+
+	chanStandings := make(chan []StandingsRow)
+	go func() {
+		defer close(chanStandings)
+
+		for page := uint(1); page <= pageCount; page++ {
+			select {
+			case chanStandings <- rows:
+			case <-ctx.Done():
+				return
+			}
+
+			if len(rows) < size || page == pageCount {
+				// All pages sent.
+				return
+			}
+			if rows, err = a.standings(ctx, arg, opts, int(page)*size+1, size); err != nil {
+				return
+			}
+		}
+	}()
+	return chanStandings, nil
+}
@@ -0,0 +1,547 @@
+package codeforces
+
+import (
+	"context"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	mockAPIKey    = "0123456789abcdef"
+	mockAPISecret = "fedcba9876543210"
+)
+
+// newMockAPI returns a local server standing in for the API of
+// the website. Results of each method are read from the file
+// 'testdata/api/<method>.json' (or '<method>.gym.json' for gyms),
+// and paginated as requested. Signed requests are verified.
+func newMockAPI(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	fail := func(w http.ResponseWriter, comment string) {
+		json.NewEncoder(w).Encode(map[string]string{"status": "FAILED", "comment": comment})
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method := strings.TrimPrefix(r.URL.Path, "/api/")
+		params := r.URL.Query()
+
+		// Signatures are checked independently of apiClient.sign,
+		// which is checked against the documented example.
+		if sig := params.Get("apiSig"); sig != "" {
+			params.Del("apiSig")
+			keys := make([]string, 0, len(params))
+			for key := range params {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+
+			var query []string
+			for _, key := range keys {
+				vals := append([]string(nil), params[key]...)
+				sort.Strings(vals)
+				for _, val := range vals {
+					query = append(query, key+"="+val)
+				}
+			}
+
+			hash := sha512.Sum512([]byte(fmt.Sprintf("%v/%v?%v#%v",
+				sig[:6], method, strings.Join(query, "&"), mockAPISecret)))
+			if params.Get("apiKey") != mockAPIKey || sig[6:] != hex.EncodeToString(hash[:]) {
+				fail(w, "apiSig: Incorrect signature")
+				return
+			}
+		}
+
+		// Page shown while the website is unavailable.
+		if method == "unavailable" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, "<html><body>Codeforces is temporarily unavailable</body></html>")
+			return
+		}
+
+		if handle := params.Get("handles"); handle != "" && handle != "tourist" {
+			fail(w, fmt.Sprintf("handles: User with handle %v not found", handle))
+			return
+		}
+		if id := params.Get("contestId"); id == "999" {
+			fail(w, fmt.Sprintf("contestId: Contest with id %v not found", id))
+			return
+		}
+
+		file := filepath.Join("testdata", "api", method+".json")
+		if params.Get("gym") == "true" {
+			file = filepath.Join("testdata", "api", method+".gym.json")
+		}
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			fail(w, "Method not found")
+			return
+		}
+
+		var resp struct {
+			Status string          `json:"status"`
+			Result json.RawMessage `json:"result"`
+		}
+		json.Unmarshal(data, &resp)
+
+		// Paginate results, if requested.
+		var rows []json.RawMessage
+		if json.Unmarshal(resp.Result, &rows) == nil && params.Get("count") != "" {
+			from, _ := strconv.Atoi(params.Get("from"))
+			count, _ := strconv.Atoi(params.Get("count"))
+			if from == 0 {
+				from = 1
+			}
+			if from-1 > len(rows) {
+				from = len(rows) + 1
+			}
+			if from-1+count > len(rows) {
+				count = len(rows) - from + 1
+			}
+			resp.Result, _ = json.Marshal(rows[from-1 : from-1+count])
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// newAPITestClient returns a client using the mock API as source.
+func newAPITestClient(t *testing.T, key, secret string) *Client {
+	t.Helper()
+
+	c, err := NewClient(Options{
+		Source:    SourceAPI,
+		HostURL:   newMockAPI(t).URL,
+		APIKey:    key,
+		APISecret: secret,
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return c
+}
+
+func Test_apiClient_call(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		secret  string
+		method  string
+		wantErr error
+	}{
+		{
+			name:    "Test #1", // Anonymous request.
+			method:  "contest.list",
+			wantErr: nil,
+		},
+		{
+			name:    "Test #2", // Signed request.
+			key:     mockAPIKey,
+			secret:  mockAPISecret,
+			method:  "contest.list",
+			wantErr: nil,
+		},
+		{
+			name:    "Test #3",
+			key:     mockAPIKey,
+			secret:  "invalid-secret",
+			method:  "contest.list",
+			wantErr: ErrAccessDenied,
+		},
+		{
+			name:    "Test #4",
+			method:  "invalid.method",
+			wantErr: ErrAPIFailed,
+		},
+		{
+			name:    "Test #5", // Response is not JSON.
+			method:  "unavailable",
+			wantErr: ErrAPIFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newAPIClient(newMockAPI(t).URL, tt.key, tt.secret)
+
+			var result []apiContest
+			err := a.call(context.Background(), tt.method, nil, &result)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("apiClient.call() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && len(result) != 2 {
+				t.Errorf("apiClient.call() result = %v", result)
+			}
+		})
+	}
+}
+
+func Test_apiClient_call_limit(t *testing.T) {
+	// Call limit is exceeded on the first 'limited' calls.
+	calls, limited := 0, 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls++; calls <= limited {
+			fmt.Fprint(w, `{"status":"FAILED","comment":"Call limit exceeded"}`)
+			return
+		}
+		fmt.Fprint(w, `{"status":"OK","result":[]}`)
+	}))
+	defer srv.Close()
+
+	a := newAPIClient(srv.URL, "", "")
+	a.retryAfter = time.Millisecond
+
+	tests := []struct {
+		name      string
+		limited   int
+		wantCalls int
+		wantErr   error
+	}{
+		{
+			name:      "Test #1",
+			limited:   3,
+			wantCalls: 4,
+			wantErr:   nil,
+		},
+		{
+			name:      "Test #2", // Retries are bounded.
+			limited:   100,
+			wantCalls: apiMaxRetries + 1,
+			wantErr:   ErrAPIFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls, limited = 0, tt.limited
+
+			var result []apiContest
+			err := a.call(context.Background(), "contest.list", nil, &result)
+			if !errors.Is(err, tt.wantErr) || calls != tt.wantCalls {
+				t.Errorf("apiClient.call() error = %v, calls = %v, want %v, %v", err, calls, tt.wantErr, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func Test_apiClient_sign(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		params url.Values
+		want   string
+	}{
+		{
+			name:   "Test #1", // Example in the API documentation.
+			method: "contest.hacks",
+			params: url.Values{"contestId": {"566"}},
+			want:   "123456a396b58b8024335c026803413678a4c13703ccce4b4b1982415a5711b9f016e3419e8e66c8f40db564d2c617dd74c521b6c3637469beb6823b212b3aa3cf5c3d",
+		},
+		{
+			name:   "Test #2", // Sorted by key, then value ('a' before 'a-b').
+			method: "user.info",
+			params: url.Values{"a-b": {"1"}, "a": {"1"}},
+			want:   "1234561581dc9e01d6e75d77f5eff8ca6a275d196737842fcb787d01c60610526c9e039870e6145b2c0c304738e2cb19b2b5334c6fd0bf59084ec5dff6fa8b21d7585c",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newAPIClient("", "xxx", "yyy")
+			a.now = func() time.Time { return time.Unix(1430118000, 0) }
+			a.nonce = func() string { return "123456" }
+
+			a.sign(tt.method, tt.params)
+			if got := tt.params.Get("apiSig"); got != tt.want {
+				t.Errorf("apiClient.sign() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_apiSubmission_toSubmission(t *testing.T) {
+	tests := []struct {
+		name        string
+		verdict     string
+		wantVerdict string
+		wantStatus  int
+		wantJudging bool
+	}{
+		{
+			name:        "Test #1",
+			verdict:     "PRESENTATION_ERROR",
+			wantVerdict: "Presentation error on test 3",
+			wantStatus:  VerdictPE,
+		},
+		{
+			name:        "Test #2",
+			verdict:     "PARTIAL",
+			wantVerdict: "Partial result",
+			wantStatus:  VerdictPartial,
+		},
+		{
+			name:        "Test #3",
+			verdict:     "TESTING",
+			wantVerdict: "Running on test 3",
+			wantJudging: true,
+		},
+		{
+			name:        "Test #4",
+			verdict:     "",
+			wantVerdict: "In queue",
+			wantJudging: true,
+		},
+		{
+			name:        "Test #5", // Unknown verdicts are final too.
+			verdict:     "SOME_NEW_VERDICT",
+			wantVerdict: "SOME_NEW_VERDICT",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := apiSubmission{ContestID: 4, Verdict: tt.verdict, PassedTestCount: 2}
+			s.Problem.Index = "A"
+
+			got := s.toSubmission(Args{})
+			if got.Verdict != tt.wantVerdict || got.VerdictStatus != tt.wantStatus || got.IsJudging != tt.wantJudging {
+				t.Errorf("apiSubmission.toSubmission() = %v, want verdict %q, status %v, judging %v",
+					got, tt.wantVerdict, tt.wantStatus, tt.wantJudging)
+			}
+		})
+	}
+}
+
+func TestClient_GetContests_api(t *testing.T) {
+	c := newAPITestClient(t, mockAPIKey, mockAPISecret)
+	defer c.Close()
+
+	tests := []struct {
+		name    string
+		arg     Args
+		want    []Contest
+		wantErr error
+	}{
+		{
+			name: "Test #1",
+			arg:  Args{"4", "", "contest", ""},
+			want: []Contest{
+				{
+					Name:      "Codeforces Beta Round #4 (Div. 2 Only)",
+					StartTime: time.Unix(1262878200, 0).UTC(),
+					Duration:  time.Hour * 2,
+					RegCount:  RegistrationNotExists,
+					RegStatus: RegistrationNotExists,
+					Arg:       Args{"4", "", "contest", ""},
+				},
+			},
+		},
+		{
+			name: "Test #2",
+			arg:  Args{"", "", "gym", ""},
+			want: []Contest{
+				{
+					Name:      "2014 ACM-ICPC Vietnam National First Round",
+					Writers:   []string{"I_love_Hoang_Yen"},
+					StartTime: time.Unix(1413709200, 0).UTC(),
+					Duration:  time.Hour * 5,
+					RegCount:  RegistrationNotExists,
+					RegStatus: RegistrationNotExists,
+					Arg:       Args{"100499", "", "gym", ""},
+				},
+			},
+		},
+		{
+			name:    "Test #3",
+			arg:     Args{"999", "", "contest", ""},
+			wantErr: ErrContestNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chanContests, err := c.GetContests(tt.arg, 1)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Client.GetContests() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			var got []Contest
+			for contests := range chanContests {
+				got = append(got, contests...)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Client.GetContests() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_GetSubmissions_api(t *testing.T) {
+	c := newAPITestClient(t, "", "")
+	defer c.Close()
+
+	want := []Submission{
+		{
+			ID:            "81327550",
+			When:          time.Unix(1590347640, 0).UTC(),
+			Who:           "cp-tools",
+			Problem:       "A - Watermelon",
+			Language:      "GNU C++17",
+			Verdict:       "Compilation error",
+			VerdictStatus: VerdictCE,
+			Time:          "0 ms",
+			Memory:        "0 KB",
+			Participant:   ParticipantPractice,
+			Arg:           Args{"4", "a", "contest", ""},
+		},
+		{
+			ID:            "81012854",
+			When:          time.Unix(1590235800, 0).UTC(),
+			Who:           "cp-tools",
+			Problem:       "B - Before an Exam",
+			Language:      "Ruby",
+			Verdict:       "Runtime error on test 2",
+			VerdictStatus: VerdictRTE,
+			Time:          "46 ms",
+			Memory:        "3600 KB",
+			TimeDuration:  time.Millisecond * 46,
+			MemoryBytes:   3600 << 10,
+			Participant:   ParticipantVirtual,
+			Arg:           Args{"4", "b", "contest", ""},
+		},
+	}
+
+	// Submissions being judged are included regardless of verdict.
+	opts := SubmissionsOptions{Verdicts: []int{VerdictCE, VerdictRTE}}
+	for _, arg := range []Args{{}, {"4", "", "contest", ""}} {
+		chanSubmissions, err := c.GetSubmissionsWithOptions(arg, "cp-tools", 2, opts)
+		if err != nil {
+			t.Fatalf("Client.GetSubmissionsWithOptions() error = %v", err)
+		}
+
+		var got []Submission
+		for submissions := range chanSubmissions {
+			for _, sub := range submissions {
+				if !sub.IsJudging {
+					got = append(got, sub)
+				}
+			}
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Client.GetSubmissionsWithOptions() = %v, want %v", got, want)
+		}
+	}
+
+	// Status of the problemset.
+	chanSubmissions, err := c.GetStatus(Args{"", "", "contest", ""}, 1,
+		SubmissionsOptions{Participants: []int{ParticipantVirtual}})
+	if err != nil {
+		t.Fatalf("Client.GetStatus() error = %v", err)
+	}
+	if got := <-chanSubmissions; !reflect.DeepEqual(got, want[1:]) {
+		t.Errorf("Client.GetStatus() = %v, want %v", got, want[1:])
+	}
+}
+
+func TestClient_GetStandings_api(t *testing.T) {
+	c := newAPITestClient(t, "", "")
+	defer c.Close()
+
+	chanStandings, err := c.GetStandings(Args{"4", "", "contest", ""}, 1, StandingsOptions{Unofficial: true})
+	if err != nil {
+		t.Fatalf("Client.GetStandings() error = %v", err)
+	}
+
+	want := []StandingsRow{
+		{
+			Rank:    1,
+			Members: []string{"tourist"},
+			Points:  2,
+			Penalty: 54,
+			Results: []ProblemResult{
+				{Problem: "a", Points: 1, Accepted: true, AcceptedAt: time.Minute * 4},
+				{Problem: "b", Points: 1, Accepted: true, AcceptedAt: time.Minute * 31, Rejected: 1},
+			},
+		},
+		{
+			Team:              "Tools",
+			Members:           []string{"cp-tools", "cp-tools-bot"},
+			Unofficial:        true,
+			Points:            1,
+			Penalty:           10,
+			SuccessfulHacks:   1,
+			UnsuccessfulHacks: 2,
+			Results: []ProblemResult{
+				{Problem: "a", Points: 1, Accepted: true, AcceptedAt: time.Minute * 10},
+				{Problem: "b", Rejected: 3},
+			},
+		},
+	}
+	if got := <-chanStandings; !reflect.DeepEqual(got, want) {
+		t.Errorf("Client.GetStandings() = %v, want %v", got, want)
+	}
+
+	if _, err := c.GetStandings(Args{"999", "", "contest", ""}, 1, StandingsOptions{}); !errors.Is(err, ErrContestNotFound) {
+		t.Errorf("Client.GetStandings() error = %v, want %v", err, ErrContestNotFound)
+	}
+}
+
+func TestClient_GetProblemset_api(t *testing.T) {
+	c := newAPITestClient(t, "", "")
+	defer c.Close()
+
+	chanProblems, err := c.GetProblemset(ProblemsetFilter{Tags: []string{"DP"}, MinRating: 1500}, 1)
+	if err != nil {
+		t.Fatalf("Client.GetProblemset() error = %v", err)
+	}
+
+	want := []Problem{
+		{
+			Name:        "Mysterious Present",
+			Tags:        []string{"dp", "sortings"},
+			Rating:      1700,
+			SolveCount:  12409,
+			SolveStatus: SolveNotAttempted,
+			Arg:         Args{"4", "d", "contest", ""},
+		},
+	}
+	if got := <-chanProblems; !reflect.DeepEqual(got, want) {
+		t.Errorf("Client.GetProblemset() = %v, want %v", got, want)
+	}
+}
+
+func TestClient_GetUser_api(t *testing.T) {
+	c := newAPITestClient(t, "", "")
+	defer c.Close()
+
+	got, err := c.GetUser("tourist")
+	if err != nil {
+		t.Fatalf("Client.GetUser() error = %v", err)
+	}
+
+	// Same as parsed from the profile.
+	want, _ := getUser(loadFixture(t, "profile.html"))
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Client.GetUser() = %v, want %v", got, want)
+	}
+
+	if _, err := c.GetUser("nobody"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Client.GetUser() error = %v, want %v", err, ErrUserNotFound)
+	}
+}
@@ -2,11 +2,14 @@ package codeforces
 
 import (
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/cp-tools/cpt-lib/v2/util"
 
@@ -24,8 +27,83 @@ type (
 		Group   string
 	}
 
+	// Client is an independent codeforces session. Each client
+	// owns its automated browser, and can hence be used alongside
+	// other clients (different accounts, browser profiles etc).
+	Client struct {
+		// Browser is the headless browser used by the client.
+		// Unless the source is SourceBrowser, it is nil till
+		// first needed (see Options.Source).
+		Browser *rod.Browser
+
+		host     string
+		cassette *util.Cassette
+		// api serves read-only data, if the source is SourceAPI.
+		api *apiClient
+		// web serves read-only data, if the source is SourceHTTP.
+		web *webClient
+
+		// launch launches the browser, once first needed.
+		mu     sync.Mutex
+		launch func() (*rod.Browser, error)
+	}
+
+	// Options holds configuration to initiate a new Client with.
+	Options struct {
+		// Headless runs the browser in headless mode.
+		Headless bool
+		// UserDataDir is the browser profile to copy cookies
+		// (logged in session) from. Ignored if empty.
+		UserDataDir string
+		// Bin is the browser binary to use.
+		Bin string
+		// CacheDir is the directory to store browser data in.
+		// Defaults to 'cp-tools/cpt-lib' in the user cache directory.
+		CacheDir string
+		// Profile names the browser profile (in CacheDir) of the
+		// client, which persists the session across launches.
+		// Clients used alongside must use different profiles.
+		// Defaults to the profile shared with Start.
+		Profile string
+		// HostURL is the base url of the website. Set to any
+		// of Mirrors to use a mirror. Defaults to HostMain.
+		HostURL string
+		// Cassette, if set, records all network responses of the
+		// client to it, or replays them from it (see util.Cassette).
+		Cassette *util.Cassette
+
+		// Source is the backend read-only data (contests,
+		// submissions, standings...) is fetched from. Defaults
+		// to SourceBrowser. With any other source, the browser
+		// is launched only once an operation needs it.
+		Source int
+		// APIKey and APISecret sign requests to the API (see
+		// SourceAPI), to access private data. Generate these
+		// in the settings of the website. Optional.
+		APIKey    string
+		APISecret string
+		// Jar holds the cookies (logged in session) of pages
+		// fetched over plain HTTP (see SourceHTTP). Cookies of
+		// the browser replace these on login and logout.
+		// Defaults to an empty jar.
+		Jar http.CookieJar
+	}
+
+	// SiteError is an error notified by the website. Err is the
+	// library error the notification corresponds to, and Msg is
+	// the original message, in the interface language of the site.
+	// Use errors.Is to check for the corresponding library error.
+	SiteError struct {
+		Err error
+		Msg string
+	}
+
 	page struct {
 		*rod.Page
+		// origin is the page without any context bound.
+		origin *rod.Page
+		// host is the host url the page was loaded from.
+		host string
 	}
 )
 
@@ -36,15 +114,78 @@ const (
 	ClassGym     = "gym"
 )
 
+// Sources of read-only data of a client (see Options.Source).
+const (
+	// SourceBrowser parses pages loaded in the automated browser.
+	SourceBrowser = iota
+	// SourceAPI fetches data through the official JSON API.
+	// Operations the API doesn't serve use the browser.
+	SourceAPI
+	// SourceHTTP fetches pages over plain HTTP, and parses them
+	// without running any scripts. Thus, no browser is needed,
+	// except for operations that interact with pages (logging
+	// in, submitting, registering...).
+	SourceHTTP
+)
+
+// Host urls of codeforces and its mirrors.
+const (
+	HostMain = "https://codeforces.com"
+	HostM1   = "https://m1.codeforces.com"
+	HostM2   = "https://m2.codeforces.com"
+	HostM3   = "https://m3.codeforces.com"
+	HostML   = "https://codeforces.ml"
+)
+
 // Errors returned by library.
 var (
 	ErrInvalidSpecifier = fmt.Errorf("invalid specifier data")
+	ErrInvalidHost      = fmt.Errorf("invalid host url")
+	ErrNoClient         = fmt.Errorf("client not initiated")
+
+	ErrInvalidCredentials  = fmt.Errorf("invalid login credentials")
+	ErrNotLoggedIn         = fmt.Errorf("no logged in session present")
+	ErrLanguageNotAllowed  = fmt.Errorf("language not allowed in problem")
+	ErrInvalidLanguage     = fmt.Errorf("invalid language")
+	ErrSubmissionClosed    = fmt.Errorf("problem not open for submission")
+	ErrDuplicateSubmission = fmt.Errorf("exact submission done before")
+	ErrContestNotFound     = fmt.Errorf("contest not found")
+	ErrAccessDenied        = fmt.Errorf("access denied")
+	ErrRedirected          = fmt.Errorf("page redirected")
+	ErrRegistrationClosed  = fmt.Errorf("registration not open")
+	ErrVirtualNotAllowed   = fmt.Errorf("virtual participation not allowed")
+	ErrAPIFailed           = fmt.Errorf("api request failed")
+	ErrRequestFailed       = fmt.Errorf("page request failed")
+	ErrUserNotFound        = fmt.Errorf("user not found")
 )
 
+// Error returns the original message shown by the website.
+func (e *SiteError) Error() string {
+	if e.Msg == "" {
+		return e.Err.Error()
+	}
+	return e.Msg
+}
+
+// Unwrap returns the corresponding library error.
+func (e *SiteError) Unwrap() error {
+	return e.Err
+}
+
 var (
-	hostURL = "https://codeforces.com"
+	hostURL = HostMain
+
+	// Mirrors lists the host urls of all mirrors of codeforces.
+	Mirrors = []string{HostM1, HostM2, HostM3, HostML}
+
+	// DefaultClient is the client used by methods of Args and
+	// Submission. It is initiated by Start (or StartWithCacheDir).
+	DefaultClient *Client
 
-	// Browser is the headless browser to use.
+	// Browser is the headless browser of DefaultClient.
+	// It is set by Start (or StartWithCacheDir).
+	//
+	// Deprecated: Use DefaultClient.Browser instead.
 	Browser *rod.Browser
 )
 
@@ -64,20 +205,123 @@ func (arg Args) String() (str string) {
 	return strings.Join(strings.Fields(str), " ")
 }
 
+// NewClient initiates a new client (and its automated browser)
+// using the given options.
+func NewClient(opts Options) (*Client, error) {
+	if opts.CacheDir == "" {
+		cacheDir, _ := os.UserCacheDir()
+		opts.CacheDir = filepath.Join(cacheDir, "cp-tools", "cpt-lib")
+	}
+
+	if opts.HostURL == "" {
+		opts.HostURL = hostURL
+	}
+
+	// Validate the host url.
+	if u, err := url.Parse(opts.HostURL); err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, ErrInvalidHost
+	}
+
+	c := &Client{
+		host:     strings.TrimSuffix(opts.HostURL, "/"),
+		cassette: opts.Cassette,
+		launch: func() (*rod.Browser, error) {
+			return util.NewBrowserWithProfile(opts.Headless, opts.UserDataDir, opts.Bin, opts.CacheDir, opts.Profile)
+		},
+	}
+
+	switch opts.Source {
+	case SourceBrowser:
+		if _, err := c.browser(); err != nil {
+			return nil, err
+		}
+
+	case SourceAPI:
+		c.api = newAPIClient(c.host, opts.APIKey, opts.APISecret)
+
+	case SourceHTTP:
+		c.web = newWebClient(c.host, opts.Jar)
+
+	default:
+		return nil, fmt.Errorf("invalid source")
+	}
+	return c, nil
+}
+
+// browser returns the automated browser of the client,
+// launching it first, if not done yet.
+func (c *Client) browser() (*rod.Browser, error) {
+	if c == nil {
+		return nil, ErrNoClient
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Browser == nil && c.launch != nil {
+		bs, err := c.launch()
+		if err != nil {
+			return nil, err
+		}
+		c.Browser, c.launch = bs, nil
+	}
+
+	if c.Browser == nil {
+		return nil, ErrNoClient
+	}
+	return c.Browser, nil
+}
+
+// Close closes the automated browser of the client.
+func (c *Client) Close() error {
+	if c == nil {
+		return ErrNoClient
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Browser == nil {
+		if c.launch != nil {
+			// Browser was never launched.
+			c.launch = nil
+			return nil
+		}
+		return ErrNoClient
+	}
+	return c.Browser.Close()
+}
+
+// Host returns the host url the client uses.
+// Falls back to HostMain if client is nil.
+func (c *Client) Host() string {
+	if c == nil || c.host == "" {
+		return hostURL
+	}
+	return c.host
+}
+
 // Start initiates the automated browser to use.
 func Start(headless bool, userDataDir, bin string) error {
-	cacheDir, _ := os.UserCacheDir()
-	cacheDir = filepath.Join(cacheDir, "cp-tools", "cpt-lib")
-
-	return StartWithCacheDir(headless, userDataDir, bin, cacheDir)
+	return StartWithCacheDir(headless, userDataDir, bin, "")
 }
 
 // StartWithCacheDir is the same as Start, only allows to set cacheDir to use.
+//
+// The started session is set as DefaultClient.
 func StartWithCacheDir(headless bool, userDataDir, bin, cacheDir string) error {
-	bs, err := util.NewBrowser(headless, userDataDir, bin, cacheDir)
-	Browser = bs
+	c, err := NewClient(Options{
+		Headless:    headless,
+		UserDataDir: userDataDir,
+		Bin:         bin,
+		CacheDir:    cacheDir,
+	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	DefaultClient, Browser = c, c.Browser
+	return nil
 }
 
 // Parse passed in specifier string to new Args struct.
@@ -88,13 +332,15 @@ func Parse(str string) (Args, error) {
 		rxProb  = `(?P<prob>[A-Za-z][1-9]?)`
 		rxClass = `(?P<class>contest|gym|group)`
 		rxGroup = `(?P<group>\w{10})`
+		// Matches main site and all mirrors (and nothing else).
+		rxHost = `^(?:https?:\/\/)?(?:www\.|m[1-3]\.)?codeforces\.(?:com|ml)`
 
 		valRx = []string{
-			`codeforces.com\/` + rxClass + `\/` + rxCont + `$`,
-			`codeforces.com\/` + rxClass + `\/` + rxCont + `\/problem\/` + rxProb + `$`,
-			`codeforces.com\/` + rxClass + `\/` + rxGroup + `\/` + `contest` + `\/` + rxCont + `$`,
-			`codeforces.com\/` + rxClass + `\/` + rxGroup + `\/` + `contest` + `\/` + rxCont + `\/problem\/` + rxProb + `$`,
-			`codeforces.com\/problemset\/problem\/` + rxCont + `\/` + rxProb + `$`,
+			rxHost + `\/` + rxClass + `\/` + rxCont + `$`,
+			rxHost + `\/` + rxClass + `\/` + rxCont + `\/problem\/` + rxProb + `$`,
+			rxHost + `\/` + rxClass + `\/` + rxGroup + `\/` + `contest` + `\/` + rxCont + `$`,
+			rxHost + `\/` + rxClass + `\/` + rxGroup + `\/` + `contest` + `\/` + rxCont + `\/problem\/` + rxProb + `$`,
+			rxHost + `\/problemset\/problem\/` + rxCont + `\/` + rxProb + `$`,
 
 			`^\s*` + rxClass + `$`,
 			`^\s*` + rxGroup + `$`,
@@ -1,6 +1,7 @@
 package codeforces
 
 import (
+	"flag"
 	"fmt"
 	"os"
 	"reflect"
@@ -9,40 +10,6 @@ import (
 	"github.com/joho/godotenv"
 )
 
-func login(usr, passwd string) (string, error) {
-	p, err := loadPage(fmt.Sprintf("%v/enter", hostURL))
-	if err != nil {
-		return "", err
-	}
-	defer p.Close()
-
-	if _, err := p.Race().Element(`#jGrowl .message`).Handle(handleErrMsg).
-		Element(`#footer`).Do(); err != nil {
-		return "", err
-	}
-
-	// Check if current user sesion is logged in.
-	if elm := p.MustElements(`#header a[href^="/profile/"]`).First(); elm != nil {
-		return elm.MustText(), nil
-	}
-
-	// Otherwise, login.
-	p.MustElement("#handleOrEmail").Input(usr)
-	p.MustElement("#password").Input(passwd)
-	if p.MustElement("#remember").MustProperty("checked").Bool() == false {
-		p.MustElement("#remember").MustClick()
-	}
-	p.MustElement(".submit").MustClick().WaitInvisible()
-
-	elm, err := p.Race().Element(`.error`).Handle(handleErrMsg).
-		Element(`#header a[href^="/profile/"]`).Do()
-	if err != nil {
-		return "", err
-	}
-
-	return elm.MustText(), nil
-}
-
 func getLoginCredentials() (string, string) {
 	// setup login access to use
 	usr := os.Getenv("CODEFORCES_USERNAME")
@@ -50,10 +17,28 @@ func getLoginCredentials() (string, string) {
 	return usr, passwd
 }
 
+// offline is set when tests are run without a browser and
+// login credentials. Only tests using fixtures are run then.
+var offline bool
+
+// skipOffline skips tests that query the remote website.
+func skipOffline(t *testing.T) {
+	if offline {
+		t.Skip("skipping test in offline mode")
+	}
+}
+
 func TestMain(m *testing.M) {
 	// Load local .env file.
 	godotenv.Load()
 
+	flag.Parse()
+	if usr, _ := getLoginCredentials(); usr == "" || testing.Short() {
+		fmt.Println("Login credentials not set (or -short); running offline tests only")
+		offline = true
+		os.Exit(m.Run())
+	}
+
 	_, browserHeadless := os.LookupEnv("BROWSER_HEADLESS")
 	browserBin := os.Getenv("BROWSER_BINARY")
 	if err := StartWithCacheDir(browserHeadless, "", browserBin, "tmp"); err != nil {
@@ -61,16 +46,16 @@ func TestMain(m *testing.M) {
 		os.Exit(1)
 	}
 
-	if handle, err := login(getLoginCredentials()); err != nil {
+	if handle, err := Login(getLoginCredentials()); err != nil {
 		fmt.Println("Login failed:", err)
-		Browser.Close()
+		DefaultClient.Close()
 		os.Exit(1)
 	} else {
 		fmt.Println("Logged in user:", handle)
 	}
 	exitCode := m.Run()
 
-	Browser.Close()
+	DefaultClient.Close()
 
 	os.Exit(exitCode)
 }
@@ -238,6 +223,30 @@ func TestParse(t *testing.T) {
 			want:    Args{},
 			wantErr: true,
 		},
+		{
+			name:    "Test #18", // Mirror.
+			args:    args{"https://m2.codeforces.com/contest/739/problem/E"},
+			want:    Args{"739", "e", "contest", ""},
+			wantErr: false,
+		},
+		{
+			name:    "Test #19", // Mirror.
+			args:    args{"https://codeforces.ml/group/MEqF8b6wBT/contest/277493/problem/g"},
+			want:    Args{"277493", "g", "group", "MEqF8b6wBT"},
+			wantErr: false,
+		},
+		{
+			name:    "Test #20", // Not a host of the website.
+			args:    args{"https://notcodeforces.com.evil/contest/1"},
+			want:    Args{},
+			wantErr: true,
+		},
+		{
+			name:    "Test #21", // Not a host of the website.
+			args:    args{"https://notcodeforces.com/contest/1"},
+			want:    Args{},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -252,3 +261,30 @@ func TestParse(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_noBrowser(t *testing.T) {
+	c := &Client{host: "https://codeforces.com"}
+
+	if _, err := c.GetProblems(Args{"4", "a", "contest", ""}); err != ErrNoClient {
+		t.Errorf("Client.GetProblems() error = %v, want %v", err, ErrNoClient)
+	}
+
+	if _, err := c.Login("cp-tools", "password"); err != ErrNoClient {
+		t.Errorf("Client.Login() error = %v, want %v", err, ErrNoClient)
+	}
+
+	if _, err := c.SubmitSource(Args{"4", "a", "contest", ""}, "GNU G++17 7.3.0", "int main() {}"); err != ErrNoClient {
+		t.Errorf("Client.SubmitSource() error = %v, want %v", err, ErrNoClient)
+	}
+
+	var nilClient *Client
+	if _, err := nilClient.GetCountdown(Args{"4", "", "contest", ""}); err != ErrNoClient {
+		t.Errorf("Client.GetCountdown() error = %v, want %v", err, ErrNoClient)
+	}
+
+	// Link builders don't require the browser.
+	want := "https://codeforces.com/contest/4/problem/a"
+	if got, _ := nilClient.ProblemsPage(Args{"4", "a", "contest", ""}); got != want {
+		t.Errorf("Client.ProblemsPage() = %v, want %v", got, want)
+	}
+}
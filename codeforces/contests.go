@@ -1,6 +1,7 @@
 package codeforces
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -8,6 +9,7 @@ import (
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/go-rod/rod"
 )
 
 type (
@@ -42,9 +44,7 @@ const (
 	RegistrationDone
 )
 
-func (p *page) getCountdown() (time.Duration, error) {
-	pd := p.parse()
-
+func getCountdown(pd *goquery.Document) (time.Duration, error) {
 	countdownStr := pd.Find(`span.countdown>span`).AttrOr(`title`, "")
 	if countdownStr == "" {
 		countdownStr = pd.Find(`span.countdown`).Text()
@@ -62,38 +62,40 @@ func (p *page) getCountdown() (time.Duration, error) {
 // Use this function instead of GetContests to get countdown,
 // as it supports returning countdown of virtual contests too.
 func (arg Args) GetCountdown() (time.Duration, error) {
+	return DefaultClient.GetCountdown(arg)
+}
+
+// GetCountdownContext is the same as GetCountdown, using the given context.
+func (arg Args) GetCountdownContext(ctx context.Context) (time.Duration, error) {
+	return DefaultClient.GetCountdownContext(ctx, arg)
+}
+
+// GetCountdown returns the time before the given contest begins.
+// See Args.GetCountdown for more details.
+func (c *Client) GetCountdown(arg Args) (time.Duration, error) {
+	return c.GetCountdownContext(context.Background(), arg)
+}
+
+// GetCountdownContext is the same as GetCountdown, using the given context.
+func (c *Client) GetCountdownContext(ctx context.Context, arg Args) (time.Duration, error) {
 	// chan has not been implemented here since,
 	// countdown is updated on reload,
 	// and is not websocket based.
 
-	link, err := arg.CountdownPage()
+	link, err := c.CountdownPage(arg)
 	if err != nil {
 		return 0, err
 	}
 
-	p, err := loadPage(link)
+	pd, err := c.fetch(ctx, link, `#footer`)
 	if err != nil {
 		return 0, err
 	}
-	defer p.Close()
-
-	if _, err := p.Race().Element(`#jGrowl .message`).Handle(handleErrMsg).
-		Element(`#footer`).Do(); err != nil {
-		return 0, err
-	}
-
-	if p.MustInfo().URL != link {
-		// An unexpected redirect occurred.
-		// Return error notification.
-		return 0, handleErrMsg(p.MustElement(`#jGrowl .message`))
-	}
 
-	return p.getCountdown()
+	return getCountdown(pd)
 }
 
-func (p *page) getContests(arg Args) ([]Contest, error) {
-	pd := p.parse()
-
+func getContests(pd *goquery.Document, arg Args) ([]Contest, error) {
 	contests := make([]Contest, 0)
 
 	contestTableRows := pd.Find(`tr[data-contestid]`)
@@ -207,28 +209,48 @@ func (p *page) getContests(arg Args) ([]Contest, error) {
 // Each page consists of 100 rows of data, except the first page,
 // which may contain additional upcoming contests data.
 func (arg Args) GetContests(pageCount uint) (<-chan []Contest, error) {
-	link, err := arg.ContestsPage()
+	return DefaultClient.GetContests(arg, pageCount)
+}
+
+// GetContestsContext is the same as GetContests, using the given context.
+func (arg Args) GetContestsContext(ctx context.Context, pageCount uint) (<-chan []Contest, error) {
+	return DefaultClient.GetContestsContext(ctx, arg, pageCount)
+}
+
+// GetContests returns metadata of the given contest(s).
+// See Args.GetContests for more details.
+func (c *Client) GetContests(arg Args, pageCount uint) (<-chan []Contest, error) {
+	return c.GetContestsContext(context.Background(), arg, pageCount)
+}
+
+// GetContestsContext is the same as GetContests, using the given context.
+//
+// Once ctx is done, parsing is stopped, the browser tab
+// is closed and the returned channel is closed.
+func (c *Client) GetContestsContext(ctx context.Context, arg Args, pageCount uint) (<-chan []Contest, error) {
+	if c != nil && c.api != nil {
+		return c.api.pageContests(ctx, arg, pageCount)
+	}
+
+	link, err := c.ContestsPage(arg)
 	if err != nil {
 		return nil, err
 	}
 
-	p, err := loadPage(link)
+	if c != nil && c.web != nil {
+		return c.web.pageContests(ctx, arg, link, pageCount)
+	}
+
+	p, err := c.loadPage(ctx, link)
 	if err != nil {
 		return nil, err
 	}
 
-	if _, err := p.Race().Element(`#jGrowl .message`).Handle(handleErrMsg).
-		Element(`tr[data-contestid]`).Do(); err != nil {
+	if err := p.waitFor(link, `tr[data-contestid]`); err != nil {
 		p.Close()
 		return nil, err
 	}
 
-	if p.MustInfo().URL != link {
-		// An unexpected redirect occurred.
-		// Return error notification.
-		return nil, handleErrMsg(p.MustElement(`#jGrowl .message`))
-	}
-
 	// Wait till alls rows are loaded.
 	p.WaitLoad()
 
@@ -237,33 +259,38 @@ func (arg Args) GetContests(pageCount uint) (<-chan []Contest, error) {
 		defer p.Close()
 		defer close(chanContests)
 
-		for ; pageCount > 0; pageCount-- {
-			// Ignore error, write whatever is parsed.
-			contests, _ := p.getContests(arg)
-			chanContests <- contests
+		// Must methods panic once the context is done.
+		rod.Try(func() {
+			for ; pageCount > 0; pageCount-- {
+				// Ignore error, write whatever is parsed.
+				contests, _ := getContests(p.parse(), arg)
+				select {
+				case chanContests <- contests:
+				case <-ctx.Done():
+					return
+				}
 
-			if !p.MustHasR(`.pagination li>a`, `→`) || pageCount == 1 {
-				// All pages parsed.
-				break
-			}
+				if !p.MustHasR(`.pagination li>a`, `→`) || pageCount == 1 {
+					// All pages parsed.
+					break
+				}
 
-			// Move to the next page (click the next button).
-			p.MustElementR(`.pagination li>a`, `→`).MustClick().WaitInvisible()
-			p.WaitLoad()
+				// Move to the next page (click the next button).
+				p.MustElementR(`.pagination li>a`, `→`).MustClick().WaitInvisible()
+				p.WaitLoad()
 
-			// Remove upcoming contests table.
-			if arg.Class == ClassContest {
-				p.MustElements(`.contestList .datatable`).First().Remove()
+				// Remove upcoming contests table.
+				if arg.Class == ClassContest {
+					p.MustElements(`.contestList .datatable`).First().Remove()
+				}
 			}
-		}
+		})
 	}()
 
 	return chanContests, nil
 }
 
-func (p *page) getDashboard(arg Args) (Dashboard, error) {
-	pd := p.parse()
-
+func getDashboard(pd *goquery.Document, arg Args, host string) (Dashboard, error) {
 	// Dashboard data is stored to this.
 	var dashboard Dashboard
 
@@ -298,6 +325,8 @@ func (p *page) getDashboard(arg Args) (Dashboard, error) {
 				constraints := clean(noticeSel.Contents().Last().Text())
 				problem.TimeLimit = strings.Split(constraints, ", ")[0]
 				problem.MemoryLimit = strings.Split(constraints, ", ")[1]
+				problem.TimeLimitDuration = parseDuration(problem.TimeLimit)
+				problem.MemoryLimitBytes = parseMemory(problem.MemoryLimit)
 
 				// Extract input/output stream data.
 				if streamStr := clean(noticeSel.Find(`div`).Text()); streamStr == "standard input/output" {
@@ -334,39 +363,81 @@ func (p *page) getDashboard(arg Args) (Dashboard, error) {
 	// Create map to hold material links.
 	dashboard.Material = make(map[string]string)
 	pd.Find(`#sidebar li a`).Each(func(_ int, sel *goquery.Selection) {
-		dashboard.Material[hostURL+sel.AttrOr(`href`, ``)] = sel.Text()
+		dashboard.Material[host+sel.AttrOr(`href`, ``)] = sel.Text()
 	})
 
 	return dashboard, nil
 }
 
+// dashboardTagsTimeout bounds the time spent fetching
+// tags of problems in the dashboard.
+var dashboardTagsTimeout = time.Second * 5
+
 // GetDashboard returns in depth contest metadata from
 // the contest dashboard page.
 //
 // Data returned by this function is user session specific,
 // as user interaction in the contest is parsed and returned.
+//
+// Tags and Rating of problems are fetched through the API, once
+// the contest is over (they are hidden while the contest is
+// running). They are left empty if they can't be fetched (in a
+// few seconds). Problems of gyms and groups have no tags.
 func (arg Args) GetDashboard() (Dashboard, error) {
-	link, err := arg.DashboardPage()
+	return DefaultClient.GetDashboard(arg)
+}
+
+// GetDashboardContext is the same as GetDashboard, using the given context.
+func (arg Args) GetDashboardContext(ctx context.Context) (Dashboard, error) {
+	return DefaultClient.GetDashboardContext(ctx, arg)
+}
+
+// GetDashboard returns in depth contest metadata from
+// the contest dashboard page. See Args.GetDashboard for more details.
+func (c *Client) GetDashboard(arg Args) (Dashboard, error) {
+	return c.GetDashboardContext(context.Background(), arg)
+}
+
+// GetDashboardContext is the same as GetDashboard, using the given context.
+func (c *Client) GetDashboardContext(ctx context.Context, arg Args) (Dashboard, error) {
+	link, err := c.DashboardPage(arg)
 	if err != nil {
 		return Dashboard{}, err
 	}
 
-	p, err := loadPage(link)
+	pd, err := c.fetch(ctx, link, `#footer`)
 	if err != nil {
 		return Dashboard{}, err
 	}
-	defer p.Close()
 
-	if _, err := p.Race().Element(`#jGrowl .message`).Handle(handleErrMsg).
-		Element(`#footer`).Do(); err != nil {
-		return Dashboard{}, err
+	dashboard, err := getDashboard(pd, arg, c.Host())
+	if err != nil || dashboard.Countdown != 0 || arg.Class == ClassGym || arg.Class == ClassGroup {
+		// Tags are hidden while the contest is running, problems
+		// of gyms don't have any tags, and the API doesn't serve
+		// group contests.
+		return dashboard, err
 	}
 
-	if p.MustInfo().URL != link {
-		// An unexpected redirect occurred.
-		// Return error notification.
-		return Dashboard{}, handleErrMsg(p.MustElement(`#jGrowl .message`))
+	// Tags are returned (by the API) along with the standings.
+	// Tags are optional; don't hold up the dashboard for them.
+	api := newAPIClient(c.Host(), "", "")
+	if c != nil && c.api != nil {
+		api = c.api
+	}
+	tagsCtx, cancel := context.WithTimeout(ctx, dashboardTagsTimeout)
+	defer cancel()
+	problems, err := api.problems(tagsCtx, arg)
+	if err != nil {
+		// Tags are optional; ignore the error.
+		return dashboard, nil
 	}
 
-	return p.getDashboard(arg)
+	for i, problem := range dashboard.Problem {
+		for _, p := range problems {
+			if strings.EqualFold(p.Index, problem.Arg.Problem) {
+				dashboard.Problem[i].Tags, dashboard.Problem[i].Rating = p.Tags, p.Rating
+			}
+		}
+	}
+	return dashboard, nil
 }
@@ -7,6 +7,7 @@ import (
 )
 
 func TestArgs_GetCountdown(t *testing.T) {
+	skipOffline(t)
 	time.Sleep(time.Second * 10)
 
 	tests := []struct {
@@ -61,6 +62,7 @@ func TestArgs_GetCountdown(t *testing.T) {
 }
 
 func TestArgs_GetContests(t *testing.T) {
+	skipOffline(t)
 	time.Sleep(time.Second * 10)
 
 	type args struct {
@@ -283,6 +285,7 @@ func TestArgs_GetContests(t *testing.T) {
 }
 
 func TestArgs_GetDashboard(t *testing.T) {
+	skipOffline(t)
 	time.Sleep(time.Second * 10)
 
 	tests := []struct {
@@ -298,48 +301,56 @@ func TestArgs_GetDashboard(t *testing.T) {
 				Name: "Codeforces Beta Round #4 (Div. 2 Only)",
 				Problem: []Problem{
 					{
-						Name:        "Watermelon",
-						TimeLimit:   "1 s",
-						MemoryLimit: "64 MB",
-						InpStream:   "standard input",
-						OutStream:   "standard output",
-						SampleTests: nil,
-						SolveCount:  -1, // keeps changing, ignore value
-						SolveStatus: SolveAccepted,
-						Arg:         Args{"4", "a", "contest", ""},
+						Name:              "Watermelon",
+						TimeLimit:         "1 s",
+						MemoryLimit:       "64 MB",
+						TimeLimitDuration: time.Second,
+						MemoryLimitBytes:  64 << 20,
+						InpStream:         "standard input",
+						OutStream:         "standard output",
+						SampleTests:       nil,
+						SolveCount:        -1, // keeps changing, ignore value
+						SolveStatus:       SolveAccepted,
+						Arg:               Args{"4", "a", "contest", ""},
 					},
 					{
-						Name:        "Before an Exam",
-						TimeLimit:   "0.5 s",
-						MemoryLimit: "64 MB",
-						InpStream:   "standard input",
-						OutStream:   "standard output",
-						SampleTests: nil,
-						SolveCount:  -1, // keeps changing, ignore value
-						SolveStatus: SolveRejected,
-						Arg:         Args{"4", "b", "contest", ""},
+						Name:              "Before an Exam",
+						TimeLimit:         "0.5 s",
+						MemoryLimit:       "64 MB",
+						TimeLimitDuration: time.Millisecond * 500,
+						MemoryLimitBytes:  64 << 20,
+						InpStream:         "standard input",
+						OutStream:         "standard output",
+						SampleTests:       nil,
+						SolveCount:        -1, // keeps changing, ignore value
+						SolveStatus:       SolveRejected,
+						Arg:               Args{"4", "b", "contest", ""},
 					},
 					{
-						Name:        "Registration System",
-						TimeLimit:   "5 s",
-						MemoryLimit: "64 MB",
-						InpStream:   "standard input",
-						OutStream:   "standard output",
-						SampleTests: nil,
-						SolveCount:  -1, // keeps changing, ignore value
-						SolveStatus: SolveNotAttempted,
-						Arg:         Args{"4", "c", "contest", ""},
+						Name:              "Registration System",
+						TimeLimit:         "5 s",
+						MemoryLimit:       "64 MB",
+						TimeLimitDuration: time.Second * 5,
+						MemoryLimitBytes:  64 << 20,
+						InpStream:         "standard input",
+						OutStream:         "standard output",
+						SampleTests:       nil,
+						SolveCount:        -1, // keeps changing, ignore value
+						SolveStatus:       SolveNotAttempted,
+						Arg:               Args{"4", "c", "contest", ""},
 					},
 					{
-						Name:        "Mysterious Present",
-						TimeLimit:   "1 s",
-						MemoryLimit: "64 MB",
-						InpStream:   "standard input",
-						OutStream:   "standard output",
-						SampleTests: nil,
-						SolveCount:  -1, // keeps changing, ignore value
-						SolveStatus: SolveNotAttempted,
-						Arg:         Args{"4", "d", "contest", ""},
+						Name:              "Mysterious Present",
+						TimeLimit:         "1 s",
+						MemoryLimit:       "64 MB",
+						TimeLimitDuration: time.Second,
+						MemoryLimitBytes:  64 << 20,
+						InpStream:         "standard input",
+						OutStream:         "standard output",
+						SampleTests:       nil,
+						SolveCount:        -1, // keeps changing, ignore value
+						SolveStatus:       SolveNotAttempted,
+						Arg:               Args{"4", "d", "contest", ""},
 					},
 				},
 				Countdown: 0,
@@ -363,15 +374,17 @@ func TestArgs_GetDashboard(t *testing.T) {
 				Name: "Codeforces Round #590 (Div. 3)",
 				Problem: []Problem{
 					{
-						Name:        "Equalize Prices Again",
-						TimeLimit:   "1 s",
-						MemoryLimit: "256 MB",
-						InpStream:   "standard input",
-						OutStream:   "standard output",
-						SampleTests: nil,
-						SolveCount:  -1, // keeps changing, ignore value
-						SolveStatus: SolveNotAttempted,
-						Arg:         Args{"1234", "a", "contest", ""},
+						Name:              "Equalize Prices Again",
+						TimeLimit:         "1 s",
+						MemoryLimit:       "256 MB",
+						TimeLimitDuration: time.Second,
+						MemoryLimitBytes:  256 << 20,
+						InpStream:         "standard input",
+						OutStream:         "standard output",
+						SampleTests:       nil,
+						SolveCount:        -1, // keeps changing, ignore value
+						SolveStatus:       SolveNotAttempted,
+						Arg:               Args{"1234", "a", "contest", ""},
 					},
 				},
 				Countdown: 0,
@@ -389,125 +402,147 @@ func TestArgs_GetDashboard(t *testing.T) {
 				Name: "2011-2012 Petrozavodsk Summer Training Camp, Kyiv + Kharkov NU Contest",
 				Problem: []Problem{
 					{
-						Name:        "A Lot",
-						TimeLimit:   "16 s",
-						MemoryLimit: "256 MB",
-						InpStream:   "alot.in",
-						OutStream:   "alot.out",
-						SampleTests: nil,
-						SolveCount:  -1, // keeps changing, ignore value
-						SolveStatus: SolveNotAttempted,
-						Arg:         Args{"100025", "a", "gym", ""},
+						Name:              "A Lot",
+						TimeLimit:         "16 s",
+						MemoryLimit:       "256 MB",
+						TimeLimitDuration: time.Second * 16,
+						MemoryLimitBytes:  256 << 20,
+						InpStream:         "alot.in",
+						OutStream:         "alot.out",
+						SampleTests:       nil,
+						SolveCount:        -1, // keeps changing, ignore value
+						SolveStatus:       SolveNotAttempted,
+						Arg:               Args{"100025", "a", "gym", ""},
 					},
 					{
-						Name:        "Almost Average",
-						TimeLimit:   "6 s",
-						MemoryLimit: "512 MB",
-						InpStream:   "almost.in",
-						OutStream:   "almost.out",
-						SampleTests: nil,
-						SolveCount:  -1, // keeps changing, ignore value
-						SolveStatus: SolveNotAttempted,
-						Arg:         Args{"100025", "b", "gym", ""},
+						Name:              "Almost Average",
+						TimeLimit:         "6 s",
+						MemoryLimit:       "512 MB",
+						TimeLimitDuration: time.Second * 6,
+						MemoryLimitBytes:  512 << 20,
+						InpStream:         "almost.in",
+						OutStream:         "almost.out",
+						SampleTests:       nil,
+						SolveCount:        -1, // keeps changing, ignore value
+						SolveStatus:       SolveNotAttempted,
+						Arg:               Args{"100025", "b", "gym", ""},
 					},
 					{
-						Name:        "Amoeba",
-						TimeLimit:   "3 s",
-						MemoryLimit: "256 MB",
-						InpStream:   "amoeba.in",
-						OutStream:   "amoeba.out",
-						SampleTests: nil,
-						SolveCount:  -1, // keeps changing, ignore value
-						SolveStatus: SolveNotAttempted,
-						Arg:         Args{"100025", "c", "gym", ""},
+						Name:              "Amoeba",
+						TimeLimit:         "3 s",
+						MemoryLimit:       "256 MB",
+						TimeLimitDuration: time.Second * 3,
+						MemoryLimitBytes:  256 << 20,
+						InpStream:         "amoeba.in",
+						OutStream:         "amoeba.out",
+						SampleTests:       nil,
+						SolveCount:        -1, // keeps changing, ignore value
+						SolveStatus:       SolveNotAttempted,
+						Arg:               Args{"100025", "c", "gym", ""},
 					},
 					{
-						Name:        "Automaton",
-						TimeLimit:   "2 s",
-						MemoryLimit: "256 MB",
-						InpStream:   "automaton.in",
-						OutStream:   "automaton.out",
-						SampleTests: nil,
-						SolveCount:  -1, // keeps changing, ignore value
-						SolveStatus: SolveNotAttempted,
-						Arg:         Args{"100025", "d", "gym", ""},
+						Name:              "Automaton",
+						TimeLimit:         "2 s",
+						MemoryLimit:       "256 MB",
+						TimeLimitDuration: time.Second * 2,
+						MemoryLimitBytes:  256 << 20,
+						InpStream:         "automaton.in",
+						OutStream:         "automaton.out",
+						SampleTests:       nil,
+						SolveCount:        -1, // keeps changing, ignore value
+						SolveStatus:       SolveNotAttempted,
+						Arg:               Args{"100025", "d", "gym", ""},
 					},
 					{
-						Name:        "Average Palindromes",
-						TimeLimit:   "1 s",
-						MemoryLimit: "256 MB",
-						InpStream:   "palindromes.in",
-						OutStream:   "palindromes.out",
-						SampleTests: nil,
-						SolveCount:  -1, // keeps changing, ignore value
-						SolveStatus: SolveNotAttempted,
-						Arg:         Args{"100025", "e", "gym", ""},
+						Name:              "Average Palindromes",
+						TimeLimit:         "1 s",
+						MemoryLimit:       "256 MB",
+						TimeLimitDuration: time.Second,
+						MemoryLimitBytes:  256 << 20,
+						InpStream:         "palindromes.in",
+						OutStream:         "palindromes.out",
+						SampleTests:       nil,
+						SolveCount:        -1, // keeps changing, ignore value
+						SolveStatus:       SolveNotAttempted,
+						Arg:               Args{"100025", "e", "gym", ""},
 					},
 					{
-						Name:        "Continued Fraction",
-						TimeLimit:   "1 s",
-						MemoryLimit: "256 MB",
-						InpStream:   "continued.in",
-						OutStream:   "continued.out",
-						SampleTests: nil,
-						SolveCount:  -1, // keeps changing, ignore value
-						SolveStatus: SolveNotAttempted,
-						Arg:         Args{"100025", "f", "gym", ""},
+						Name:              "Continued Fraction",
+						TimeLimit:         "1 s",
+						MemoryLimit:       "256 MB",
+						TimeLimitDuration: time.Second,
+						MemoryLimitBytes:  256 << 20,
+						InpStream:         "continued.in",
+						OutStream:         "continued.out",
+						SampleTests:       nil,
+						SolveCount:        -1, // keeps changing, ignore value
+						SolveStatus:       SolveNotAttempted,
+						Arg:               Args{"100025", "f", "gym", ""},
 					},
 					{
-						Name:        "K-plets",
-						TimeLimit:   "2 s",
-						MemoryLimit: "256 MB",
-						InpStream:   "k-plets.in",
-						OutStream:   "k-plets.out",
-						SampleTests: nil,
-						SolveCount:  -1, // keeps changing, ignore value
-						SolveStatus: SolveNotAttempted,
-						Arg:         Args{"100025", "g", "gym", ""},
+						Name:              "K-plets",
+						TimeLimit:         "2 s",
+						MemoryLimit:       "256 MB",
+						TimeLimitDuration: time.Second * 2,
+						MemoryLimitBytes:  256 << 20,
+						InpStream:         "k-plets.in",
+						OutStream:         "k-plets.out",
+						SampleTests:       nil,
+						SolveCount:        -1, // keeps changing, ignore value
+						SolveStatus:       SolveNotAttempted,
+						Arg:               Args{"100025", "g", "gym", ""},
 					},
 					{
-						Name:        "NIMG",
-						TimeLimit:   "5 s",
-						MemoryLimit: "256 MB",
-						InpStream:   "nimg.in",
-						OutStream:   "nimg.out",
-						SampleTests: nil,
-						SolveCount:  -1, // keeps changing, ignore value
-						SolveStatus: SolveNotAttempted,
-						Arg:         Args{"100025", "h", "gym", ""},
+						Name:              "NIMG",
+						TimeLimit:         "5 s",
+						MemoryLimit:       "256 MB",
+						TimeLimitDuration: time.Second * 5,
+						MemoryLimitBytes:  256 << 20,
+						InpStream:         "nimg.in",
+						OutStream:         "nimg.out",
+						SampleTests:       nil,
+						SolveCount:        -1, // keeps changing, ignore value
+						SolveStatus:       SolveNotAttempted,
+						Arg:               Args{"100025", "h", "gym", ""},
 					},
 					{
-						Name:        "Semi-cool Points",
-						TimeLimit:   "1 s",
-						MemoryLimit: "256 MB",
-						InpStream:   "semi-cool.in",
-						OutStream:   "semi-cool.out",
-						SampleTests: nil,
-						SolveCount:  -1, // keeps changing, ignore value
-						SolveStatus: SolveNotAttempted,
-						Arg:         Args{"100025", "i", "gym", ""},
+						Name:              "Semi-cool Points",
+						TimeLimit:         "1 s",
+						MemoryLimit:       "256 MB",
+						TimeLimitDuration: time.Second,
+						MemoryLimitBytes:  256 << 20,
+						InpStream:         "semi-cool.in",
+						OutStream:         "semi-cool.out",
+						SampleTests:       nil,
+						SolveCount:        -1, // keeps changing, ignore value
+						SolveStatus:       SolveNotAttempted,
+						Arg:               Args{"100025", "i", "gym", ""},
 					},
 					{
-						Name:        "Stairs",
-						TimeLimit:   "1 s",
-						MemoryLimit: "256 MB",
-						InpStream:   "stairs.in",
-						OutStream:   "stairs.out",
-						SampleTests: nil,
-						SolveCount:  -1, // keeps changing, ignore value
-						SolveStatus: SolveNotAttempted,
-						Arg:         Args{"100025", "j", "gym", ""},
+						Name:              "Stairs",
+						TimeLimit:         "1 s",
+						MemoryLimit:       "256 MB",
+						TimeLimitDuration: time.Second,
+						MemoryLimitBytes:  256 << 20,
+						InpStream:         "stairs.in",
+						OutStream:         "stairs.out",
+						SampleTests:       nil,
+						SolveCount:        -1, // keeps changing, ignore value
+						SolveStatus:       SolveNotAttempted,
+						Arg:               Args{"100025", "j", "gym", ""},
 					},
 					{
-						Name:        "Number of Zeroes",
-						TimeLimit:   "1 s",
-						MemoryLimit: "256 MB",
-						InpStream:   "zeroes.in",
-						OutStream:   "zeroes.out",
-						SampleTests: nil,
-						SolveCount:  -1, // keeps changing, ignore value
-						SolveStatus: SolveNotAttempted,
-						Arg:         Args{"100025", "k", "gym", ""},
+						Name:              "Number of Zeroes",
+						TimeLimit:         "1 s",
+						MemoryLimit:       "256 MB",
+						TimeLimitDuration: time.Second,
+						MemoryLimitBytes:  256 << 20,
+						InpStream:         "zeroes.in",
+						OutStream:         "zeroes.out",
+						SampleTests:       nil,
+						SolveCount:        -1, // keeps changing, ignore value
+						SolveStatus:       SolveNotAttempted,
+						Arg:               Args{"100025", "k", "gym", ""},
 					},
 				},
 				Countdown: 0,
@@ -524,6 +559,8 @@ func TestArgs_GetDashboard(t *testing.T) {
 			// set solve count to -1
 			for i := range got.Problem {
 				got.Problem[i].SolveCount = -1
+				// Tags keep changing; checked in offline tests.
+				got.Problem[i].Tags, got.Problem[i].Rating = nil, 0
 			}
 
 			if (err != nil) != tt.wantErr {
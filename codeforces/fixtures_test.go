@@ -0,0 +1,1413 @@
+package codeforces
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// loadFixture returns the saved page parsed. All
+// fixtures are stored in the 'testdata' directory.
+func loadFixture(t *testing.T, name string) *goquery.Document {
+	t.Helper()
+
+	file, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("Failed to open fixture %v: %v", name, err)
+	}
+	defer file.Close()
+
+	pd, err := goquery.NewDocumentFromReader(file)
+	if err != nil {
+		t.Fatalf("Failed to parse fixture %v: %v", name, err)
+	}
+	return pd
+}
+
+func Test_getCountdown(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		want    time.Duration
+	}{
+		{
+			name:    "Test #1",
+			fixture: "countdown.html",
+			want:    time.Hour*25 + time.Minute*47 + time.Second*33,
+		},
+		{
+			name:    "Test #2", // Contest has started.
+			fixture: "contests_contest.html",
+			want:    0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getCountdown(loadFixture(t, tt.fixture))
+			if err != nil {
+				t.Errorf("getCountdown() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("getCountdown() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getContests(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		arg     Args
+		want    []Contest
+	}{
+		{
+			name:    "Test #1",
+			fixture: "contests_contest.html",
+			arg:     Args{"7", "", "contest", ""},
+			want: []Contest{
+				{
+					Name:        "Codeforces Beta Round #7",
+					Writers:     []string{"MikeMirzayanov", "RAD", "e-maxx"},
+					StartTime:   time.Date(2010, time.April, 1, 16, 45, 0, 0, time.UTC),
+					Duration:    time.Hour * 2,
+					RegCount:    722,
+					RegStatus:   RegistrationClosed,
+					Description: nil,
+					Arg:         Args{"7", "", "contest", ""},
+				},
+			},
+		},
+		{
+			name:    "Test #2",
+			fixture: "contests_list.html",
+			arg:     Args{"", "", "contest", ""},
+			want: []Contest{
+				{
+					Name:        "Codeforces Round #707 (Div. 1, based on Moscow Open Olympiad in Informatics)",
+					Writers:     []string{"isaf27"},
+					StartTime:   time.Date(2021, time.March, 13, 14, 5, 0, 0, time.UTC),
+					Duration:    time.Hour*2 + time.Minute*30,
+					RegCount:    4312,
+					RegStatus:   RegistrationOpen,
+					Description: nil,
+					Arg:         Args{"1500", "", "contest", ""},
+				},
+				{
+					Name:        "Codeforces Round #707 (Div. 2, based on Moscow Open Olympiad in Informatics)",
+					Writers:     []string{"isaf27", "cdkrot"},
+					StartTime:   time.Date(2021, time.March, 13, 14, 5, 0, 0, time.UTC),
+					Duration:    time.Hour*2 + time.Minute*30,
+					RegCount:    12056,
+					RegStatus:   RegistrationDone,
+					Description: nil,
+					Arg:         Args{"1501", "", "contest", ""},
+				},
+				{
+					Name:        "Educational Codeforces Round 105 (Rated for Div. 2)",
+					Writers:     []string{"BledDest", "adedalic"},
+					StartTime:   time.Date(2021, time.March, 2, 14, 35, 0, 0, time.UTC),
+					Duration:    time.Hour * 26,
+					RegCount:    19822,
+					RegStatus:   RegistrationClosed,
+					Description: nil,
+					Arg:         Args{"1494", "", "contest", ""},
+				},
+			},
+		},
+		{
+			name:    "Test #3",
+			fixture: "contests_gym.html",
+			arg:     Args{"100499", "", "gym", ""},
+			want: []Contest{
+				{
+					Name:        "2014 ACM-ICPC Vietnam National First Round",
+					Writers:     nil,
+					StartTime:   time.Date(2014, time.October, 12, 7, 0, 0, 0, time.UTC),
+					Duration:    time.Hour*5 + time.Minute*15,
+					RegCount:    RegistrationNotExists,
+					RegStatus:   RegistrationNotExists,
+					Description: []string{"Prepared by I_love_Hoang_Yen"},
+					Arg:         Args{"100499", "", "gym", ""},
+				},
+			},
+		},
+		{
+			name:    "Test #4",
+			fixture: "contests_group.html",
+			arg:     Args{"", "", "group", "7rY4CfQSjd"},
+			want: []Contest{
+				{
+					Name:        "gym problems -2",
+					Writers:     nil,
+					StartTime:   time.Date(2016, time.July, 19, 6, 30, 0, 0, time.UTC),
+					Duration:    time.Hour * 4,
+					RegCount:    RegistrationNotExists,
+					RegStatus:   RegistrationNotExists,
+					Description: []string{"Prepared by Daniar", "Training Camp Contest", "Syria, Homs", "Statements:\nin English"},
+					Arg:         Args{"207982", "", "group", "7rY4CfQSjd"},
+				},
+				{
+					Name:        "ALBAATH Rush day 9 Intermediate",
+					Writers:     nil,
+					StartTime:   time.Unix(0, 0).UTC(),
+					Duration:    time.Hour*2 + time.Minute*30,
+					RegCount:    RegistrationNotExists,
+					RegStatus:   RegistrationNotExists,
+					Description: []string{"Prepared by Marcil"},
+					Arg:         Args{"206346", "", "group", "7rY4CfQSjd"},
+				},
+			},
+		},
+		{
+			name:    "Test #5", // Russian locale.
+			fixture: "contests_ru.html",
+			arg:     Args{"7", "", "contest", ""},
+			want: []Contest{
+				{
+					Name:        "Codeforces Beta Round #7",
+					Writers:     []string{"MikeMirzayanov", "RAD", "e-maxx"},
+					StartTime:   time.Date(2010, time.April, 1, 16, 45, 0, 0, time.UTC),
+					Duration:    time.Hour * 2,
+					RegCount:    722,
+					RegStatus:   RegistrationClosed,
+					Description: nil,
+					Arg:         Args{"7", "", "contest", ""},
+				},
+			},
+		},
+		{
+			name:    "Test #6", // Contest id mismatch.
+			fixture: "contests_contest.html",
+			arg:     Args{"8", "", "contest", ""},
+			want:    []Contest{},
+		},
+		{
+			name:    "Test #7", // Error notification.
+			fixture: "error.html",
+			arg:     Args{"12345", "", "contest", ""},
+			want:    []Contest{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getContests(loadFixture(t, tt.fixture), tt.arg)
+			if err != nil {
+				t.Errorf("getContests() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getContests() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getDashboard(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		arg     Args
+		want    Dashboard
+	}{
+		{
+			name:    "Test #1",
+			fixture: "dashboard_contest.html",
+			arg:     Args{"4", "", "contest", ""},
+			want: Dashboard{
+				Name: "Codeforces Beta Round #4 (Div. 2 Only)",
+				Problem: []Problem{
+					{
+						Name:              "Watermelon",
+						TimeLimit:         "1 s",
+						MemoryLimit:       "64 MB",
+						TimeLimitDuration: time.Second,
+						MemoryLimitBytes:  64 << 20,
+						InpStream:         "standard input",
+						OutStream:         "standard output",
+						SolveCount:        197145,
+						SolveStatus:       SolveAccepted,
+						Arg:               Args{"4", "a", "contest", ""},
+					},
+					{
+						Name:              "Before an Exam",
+						TimeLimit:         "0.5 s",
+						MemoryLimit:       "64 MB",
+						TimeLimitDuration: time.Millisecond * 500,
+						MemoryLimitBytes:  64 << 20,
+						InpStream:         "standard input",
+						OutStream:         "standard output",
+						SolveCount:        26893,
+						SolveStatus:       SolveRejected,
+						Arg:               Args{"4", "b", "contest", ""},
+					},
+					{
+						Name:              "Registration System",
+						TimeLimit:         "5 s",
+						MemoryLimit:       "64 MB",
+						TimeLimitDuration: time.Second * 5,
+						MemoryLimitBytes:  64 << 20,
+						InpStream:         "standard input",
+						OutStream:         "standard output",
+						SolveCount:        37712,
+						SolveStatus:       SolveNotAttempted,
+						Arg:               Args{"4", "c", "contest", ""},
+					},
+				},
+				Countdown: 0,
+				Material: map[string]string{
+					"https://codeforces.com/blog/entry/93": "Announcement",
+					"https://codeforces.com/blog/entry/99": "Tutorial",
+				},
+			},
+		},
+		{
+			name:    "Test #2",
+			fixture: "dashboard_gym.html",
+			arg:     Args{"100025", "b", "gym", ""},
+			want: Dashboard{
+				Name: "2011-2012 Petrozavodsk Summer Training Camp, Kyiv + Kharkov NU Contest",
+				Problem: []Problem{
+					{
+						Name:              "Almost Average",
+						TimeLimit:         "6 s",
+						MemoryLimit:       "512 MB",
+						TimeLimitDuration: time.Second * 6,
+						MemoryLimitBytes:  512 << 20,
+						InpStream:         "almost.in",
+						OutStream:         "almost.out",
+						SolveCount:        0,
+						SolveStatus:       SolveNotAttempted,
+						Arg:               Args{"100025", "b", "gym", ""},
+					},
+				},
+				Countdown: time.Hour + time.Minute*20 + time.Second*30,
+				Material: map[string]string{
+					"https://codeforces.com/gym/100025/attachments/download/32/20112012-petrozavodsk-summer-training-camp-kiev-kharkov-nu-contest-en.pdf": "Statements (en)",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getDashboard(loadFixture(t, tt.fixture), tt.arg, hostURL)
+			if err != nil {
+				t.Errorf("getDashboard() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getDashboard() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getProblems(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		arg     Args
+		want    []Problem
+	}{
+		{
+			name:    "Test #1",
+			fixture: "problems_contest.html",
+			arg:     Args{"4", "", "contest", ""},
+			want: []Problem{
+				{
+					Name:              "A. Watermelon",
+					TimeLimit:         "1 second",
+					MemoryLimit:       "64 megabytes",
+					TimeLimitDuration: time.Second,
+					MemoryLimitBytes:  64 << 20,
+					InpStream:         "standard input",
+					OutStream:         "standard output",
+					SampleTests: []SampleTest{
+						{
+							Input:  "8\n",
+							Output: "YES\n",
+						},
+					},
+					Arg: Args{"4", "a", "contest", ""},
+				},
+				{
+					Name:              "B. Before an Exam",
+					TimeLimit:         "0.5 second",
+					MemoryLimit:       "64 megabytes",
+					TimeLimitDuration: time.Millisecond * 500,
+					MemoryLimitBytes:  64 << 20,
+					InpStream:         "standard input",
+					OutStream:         "standard output",
+					SampleTests: []SampleTest{
+						{
+							Input:  "1 48\n5 7\n",
+							Output: "NO\n",
+						},
+						{
+							Input:  "2 5\n0 1\n3 5\n",
+							Output: "YES\n1 4 ",
+						},
+					},
+					Arg: Args{"4", "b", "contest", ""},
+				},
+			},
+		},
+		{
+			name:    "Test #2", // Line wrapped sample tests.
+			fixture: "problems_group.html",
+			arg:     Args{"277493", "t", "group", "MEqF8b6wBT"},
+			want: []Problem{
+				{
+					Name:              "T. Rhombuses Inside Rectangle",
+					TimeLimit:         "2 seconds",
+					MemoryLimit:       "256 megabytes",
+					TimeLimitDuration: time.Second * 2,
+					MemoryLimitBytes:  256 << 20,
+					InpStream:         "rect.in",
+					OutStream:         "standard output",
+					SampleTests: []SampleTest{
+						{
+							Input:  "3\n1 1\n2 2\n2 3\n",
+							Output: "0\n1\n2\n",
+						},
+					},
+					Arg: Args{"277493", "t", "group", "MEqF8b6wBT"},
+				},
+			},
+		},
+		{
+			name:    "Test #3", // Error notification.
+			fixture: "error.html",
+			arg:     Args{"12345", "", "contest", ""},
+			want:    []Problem{},
+		},
+		{
+			name:    "Test #4", // Tags in the sidebar.
+			fixture: "problems_tags.html",
+			arg:     Args{"4", "d", "contest", ""},
+			want: []Problem{
+				{
+					Name:              "D. Mysterious Present",
+					TimeLimit:         "1 second",
+					MemoryLimit:       "64 megabytes",
+					TimeLimitDuration: time.Second,
+					MemoryLimitBytes:  64 << 20,
+					InpStream:         "standard input",
+					OutStream:         "standard output",
+					SampleTests: []SampleTest{
+						{
+							Input:  "2 1 1\n2 2\n2 2\n",
+							Output: "1\n1 \n",
+						},
+					},
+					Tags:   []string{"dp", "sortings", "*special"},
+					Rating: 1700,
+					Arg:    Args{"4", "d", "contest", ""},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getProblems(loadFixture(t, tt.fixture), tt.arg)
+			if err != nil {
+				t.Errorf("getProblems() error = %v", err)
+				return
+			}
+			// Statements are checked in Test_getStatement.
+			for i := range got {
+				got[i].Statement = Statement{}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getProblems() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getStatement(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		want    Statement
+	}{
+		{
+			name:    "Test #1",
+			fixture: "problems_group.html",
+			want: Statement{
+				Legend: Section{
+					Text: "You are given a rectangle of size $w \\times h$. Count the rhombuses with vertices in integer points lying inside the rectangle.\n$$ \\sum_{i=1}^{w} i $$",
+					HTML: "<p>You are given a rectangle of size $w \\times h$. Count the rhombuses with vertices in integer points lying inside the rectangle.</p><p>$$ \\sum_{i=1}^{w} i $$</p>",
+				},
+				Input: Section{
+					Text: "The first line contains an integer $t$ ($1 \\le t \\le 10^4$). Each of the next $t$ lines contains $w$ and $h$.",
+					HTML: "<p>The first line contains an integer $t$ ($1 \\le t \\le 10^4$). Each of the next $t$ lines contains $w$ and $h$.</p>",
+				},
+				Output: Section{
+					Text: "For each test case print the answer.",
+					HTML: "<p>For each test case print the answer.</p>",
+				},
+			},
+		},
+		{
+			name:    "Test #2", // Rendered by MathJax.
+			fixture: "problems_interactive.html",
+			want: Statement{
+				Legend: Section{
+					Text: "The jury picks an integer $x$ between $1$ and $n$. Guess it.\n$$1 \\le x \\le n$$",
+					HTML: "<p>The jury picks an integer $x$ between $1$ and $n$. Guess it.</p>$$1 \\le x \\le n$$",
+				},
+				Input: Section{
+					Text: "The only line contains $n$ ($1 \\le n \\le 10^9$).",
+					HTML: "<p>The only line contains $n$ ($1 \\le n \\le 10^9$).</p>",
+				},
+				Output: Section{
+					Text: "Print the number once guessed.",
+					HTML: "<p>Print the number once guessed.</p>",
+				},
+				Interaction: Section{
+					Text: "To ask a query print \"? $y$\".\nDo not forget to flush the output.",
+					HTML: "<p>To ask a query print &#34;? $y$&#34;.</p><p>Do not forget to flush the output.</p>",
+				},
+				Scoring: Section{
+					Text: "Each test is worth $1$ point.",
+					HTML: "<p>Each test is worth $1$ point.</p>",
+				},
+				Notes: Section{
+					Text: "The hidden number is $2$.\nFirst query.\nAnswer.",
+					HTML: "<p>The hidden number is $2$.</p><ul><li>First query.</li><li>Answer.</li></ul>",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getStatement(loadFixture(t, tt.fixture).Find(`.problem-statement`))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getStatement() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getSubmissions(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		arg     Args
+		want    []Submission
+	}{
+		{
+			name:    "Test #1",
+			fixture: "submissions.html",
+			arg:     Args{"4", "a", "contest", ""},
+			want: []Submission{
+				{
+					ID:            "81327550",
+					When:          time.Date(2020, time.May, 24, 19, 14, 0, 0, time.UTC),
+					Who:           "cp-tools",
+					Problem:       "A - Watermelon",
+					Language:      "GNU C++17",
+					Verdict:       "Compilation error",
+					VerdictStatus: VerdictCE,
+					Time:          "0 ms",
+					Memory:        "0 KB",
+					TimeDuration:  0,
+					MemoryBytes:   0,
+					IsJudging:     false,
+					Arg:           Args{"4", "a", "contest", ""},
+				},
+				{
+					ID:            "81011111",
+					When:          time.Date(2020, time.May, 23, 11, 45, 0, 0, time.UTC),
+					Who:           "cp-tools",
+					Problem:       "A - Watermelon",
+					Language:      "GNU C++17",
+					Verdict:       "Accepted",
+					VerdictStatus: VerdictAC,
+					Time:          "62 ms",
+					Memory:        "100 KB",
+					TimeDuration:  time.Millisecond * 62,
+					MemoryBytes:   100 << 10,
+					IsJudging:     false,
+					Arg:           Args{"4", "a", "contest", ""},
+				},
+			},
+		},
+		{
+			name:    "Test #2",
+			fixture: "submissions.html",
+			arg:     Args{"4", "", "contest", ""},
+			want: []Submission{
+				{
+					ID:            "81327712",
+					When:          time.Date(2020, time.May, 24, 19, 16, 0, 0, time.UTC),
+					Who:           "cp-tools",
+					Problem:       "C - Registration system",
+					Language:      "Python 3",
+					Verdict:       "Running on test 5",
+					VerdictStatus: 0,
+					Time:          "0 ms",
+					Memory:        "0 KB",
+					TimeDuration:  0,
+					MemoryBytes:   0,
+					Participant:   ParticipantOutOfCompetition,
+					IsJudging:     true,
+					Arg:           Args{"4", "c", "contest", ""},
+				},
+				{
+					ID:            "81327550",
+					When:          time.Date(2020, time.May, 24, 19, 14, 0, 0, time.UTC),
+					Who:           "cp-tools",
+					Problem:       "A - Watermelon",
+					Language:      "GNU C++17",
+					Verdict:       "Compilation error",
+					VerdictStatus: VerdictCE,
+					Time:          "0 ms",
+					Memory:        "0 KB",
+					TimeDuration:  0,
+					MemoryBytes:   0,
+					IsJudging:     false,
+					Arg:           Args{"4", "a", "contest", ""},
+				},
+				{
+					ID:            "81012854",
+					When:          time.Date(2020, time.May, 23, 12, 10, 0, 0, time.UTC),
+					Who:           "cp-tools",
+					Problem:       "B - Before an Exam",
+					Language:      "Ruby",
+					Verdict:       "Runtime error on test 2",
+					VerdictStatus: VerdictRTE,
+					Time:          "46 ms",
+					Memory:        "3600 KB",
+					TimeDuration:  time.Millisecond * 46,
+					MemoryBytes:   3600 << 10,
+					Participant:   ParticipantVirtual,
+					IsJudging:     false,
+					Arg:           Args{"4", "b", "contest", ""},
+				},
+				{
+					ID:            "81011111",
+					When:          time.Date(2020, time.May, 23, 11, 45, 0, 0, time.UTC),
+					Who:           "cp-tools",
+					Problem:       "A - Watermelon",
+					Language:      "GNU C++17",
+					Verdict:       "Accepted",
+					VerdictStatus: VerdictAC,
+					Time:          "62 ms",
+					Memory:        "100 KB",
+					TimeDuration:  time.Millisecond * 62,
+					MemoryBytes:   100 << 10,
+					IsJudging:     false,
+					Arg:           Args{"4", "a", "contest", ""},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getSubmissions(loadFixture(t, tt.fixture), tt.arg)
+			if err != nil {
+				t.Errorf("getSubmissions() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getSubmissions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getErrMsg(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		want    string
+		wantErr error
+	}{
+		{
+			name:    "Test #1",
+			fixture: "error.html",
+			want:    "No such contest",
+			wantErr: ErrContestNotFound,
+		},
+		{
+			name:    "Test #2", // Russian locale.
+			fixture: "error_ru.html",
+			want:    "Вы не можете просматривать данное соревнование",
+			wantErr: ErrAccessDenied,
+		},
+		{
+			name:    "Test #3", // No notification.
+			fixture: "problems_contest.html",
+			want:    "",
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := getErrMsg(loadFixture(t, tt.fixture))
+			if (err != nil) != (tt.want != "") {
+				t.Errorf("getErrMsg() error = %v, want %v", err, tt.want)
+				return
+			}
+			if err != nil && err.Error() != tt.want {
+				t.Errorf("getErrMsg() = %v, want %v", err, tt.want)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("getErrMsg() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_getCurrentUser(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		want    string
+	}{
+		{
+			name:    "Test #1",
+			fixture: "countdown.html",
+			want:    "cp-tools",
+		},
+		{
+			name:    "Test #2", // Not logged in.
+			fixture: "enter.html",
+			want:    "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getCurrentUser(loadFixture(t, tt.fixture)); got != tt.want {
+				t.Errorf("getCurrentUser() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getLoginResult(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		want    string
+		wantErr error
+		wantMsg string
+	}{
+		{
+			name:    "Test #1",
+			fixture: "countdown.html",
+			want:    "cp-tools",
+		},
+		{
+			name:    "Test #2", // Login rejected.
+			fixture: "enter.html",
+			wantErr: ErrInvalidCredentials,
+			wantMsg: "Invalid handle/email or password",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getLoginResult(loadFixture(t, tt.fixture))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("getLoginResult() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if err != nil && err.Error() != tt.wantMsg {
+				t.Errorf("getLoginResult() error = %v, want %v", err, tt.wantMsg)
+			}
+			if got != tt.want {
+				t.Errorf("getLoginResult() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_downloadAssets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cpt-assets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Assets are served from the local server.
+	srv := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	defer srv.Close()
+
+	arg := Args{"100499", "f", "gym", ""}
+	problems, _ := getProblems(loadFixture(t, "problems_assets.html"), arg)
+	if err := downloadAssets(context.Background(), http.DefaultClient, srv.URL, problems, dir); err != nil {
+		t.Fatalf("downloadAssets() error = %v", err)
+	}
+
+	// Links are relative to dir.
+	wantLegend := `<p>Look at the rhombus below.</p><center><img class="tex-graphics" src="f/rhombus.png"` +
+		` style="max-width: 100.0%;max-height: 100.0%;"/></center><p>Full statements are <a href="f/statements.pdf"` +
+		`>attached</a>. Discuss in the <a href="/blog/entry/1">blog</a>.</p>`
+	if got := problems[0].Statement.Legend.HTML; got != wantLegend {
+		t.Errorf("downloadAssets() legend = %v, want %v", got, wantLegend)
+	}
+	wantNotes := `<p>Same rhombus again:</p><img src="f/rhombus.png"/>`
+	if got := problems[0].Statement.Notes.HTML; got != wantNotes {
+		t.Errorf("downloadAssets() notes = %v, want %v", got, wantNotes)
+	}
+
+	for file, fixture := range map[string]string{
+		filepath.Join(dir, "f", "rhombus.png"):    "testdata/assets/rhombus.png",
+		filepath.Join(dir, "f", "statements.pdf"): "testdata/assets/attachments/statements.pdf",
+	} {
+		got, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Errorf("downloadAssets() file %v not downloaded: %v", file, err)
+			continue
+		}
+		if want, _ := ioutil.ReadFile(fixture); !reflect.DeepEqual(got, want) {
+			t.Errorf("downloadAssets() file %v = %q, want %q", file, got, want)
+		}
+	}
+
+	// Missing assets must be reported.
+	problems[0].Statement.Legend.HTML = `<img src="/assets/missing.png"/>`
+	if err := downloadAssets(context.Background(), http.DefaultClient, srv.URL, problems, dir); err == nil {
+		t.Errorf("downloadAssets() expected error for missing assets")
+	}
+}
+
+func Test_downloadMaterial(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cpt-material")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Material is served from the local server.
+	srv := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	defer srv.Close()
+
+	pd := loadFixture(t, "problems_assets.html")
+	if err := downloadMaterial(context.Background(), http.DefaultClient, srv.URL, pd, dir); err != nil {
+		t.Fatalf("downloadMaterial() error = %v", err)
+	}
+
+	// Links to pages are not downloaded.
+	files, _ := ioutil.ReadDir(dir)
+	if len(files) != 1 || files[0].Name() != "statements.pdf" {
+		t.Fatalf("downloadMaterial() files = %v, want [statements.pdf]", files)
+	}
+
+	got, _ := ioutil.ReadFile(filepath.Join(dir, "statements.pdf"))
+	if want, _ := ioutil.ReadFile("testdata/assets/attachments/statements.pdf"); !reflect.DeepEqual(got, want) {
+		t.Errorf("downloadMaterial() file = %q, want %q", got, want)
+	}
+}
+
+func Test_getStandings(t *testing.T) {
+	tests := []struct {
+		name      string
+		fixture   string
+		want      []StandingsRow
+		wantPages int
+		wantOver  bool
+	}{
+		{
+			name:    "Test #1",
+			fixture: "standings_contest.html",
+			want: []StandingsRow{
+				{
+					Rank:              1,
+					Members:           []string{"tourist"},
+					Points:            1470,
+					SuccessfulHacks:   1,
+					UnsuccessfulHacks: 2,
+					Results: []ProblemResult{
+						{Problem: "a", Points: 498, Accepted: true, AcceptedAt: time.Minute * 4},
+						{Problem: "b", Points: 872, Accepted: true, AcceptedAt: time.Hour + time.Minute*15},
+					},
+				},
+				{
+					Rank:    2,
+					Members: []string{"Petr"},
+					Points:  496,
+					Results: []ProblemResult{
+						{Problem: "a", Points: 496, Accepted: true, AcceptedAt: time.Minute * 5},
+						{Problem: "b", FailedSystemTest: true},
+					},
+				},
+				{
+					Members:    []string{"cp-tools"},
+					Unofficial: true,
+					Results: []ProblemResult{
+						{Problem: "a", Rejected: 2},
+						{Problem: "b"},
+					},
+				},
+			},
+			wantPages: 3,
+		},
+		{
+			name:    "Test #2", // Russian locale, ICPC rules.
+			fixture: "standings_gym.html",
+			want: []StandingsRow{
+				{
+					Rank:    1,
+					Team:    "HCMUS-Fireflies",
+					Members: []string{"I_love_Hoang_Yen", "ngfam_kongu"},
+					Points:  2,
+					Penalty: 83,
+					Results: []ProblemResult{
+						{Problem: "a", Points: 1, Accepted: true, AcceptedAt: time.Minute * 12},
+						{Problem: "b", Points: 1, Accepted: true, AcceptedAt: time.Minute * 51, Rejected: 1},
+					},
+				},
+				{
+					Rank:    2,
+					Members: []string{"cp-tools"},
+					Results: []ProblemResult{
+						{Problem: "a", Rejected: 3},
+						{Problem: "b"},
+					},
+				},
+			},
+			wantPages: 1,
+			wantOver:  true,
+		},
+		{
+			name:      "Test #3", // Error notification.
+			fixture:   "error.html",
+			want:      []StandingsRow{},
+			wantPages: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pd := loadFixture(t, tt.fixture)
+			got, err := getStandings(pd)
+			if err != nil {
+				t.Errorf("getStandings() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getStandings() = %+v, want %+v", got, tt.want)
+			}
+			if got := getStandingsPageCount(pd); got != tt.wantPages {
+				t.Errorf("getStandingsPageCount() = %v, want %v", got, tt.wantPages)
+			}
+			if got := isContestOver(pd, time.Time{}); got != tt.wantOver {
+				t.Errorf("isContestOver() = %v, want %v", got, tt.wantOver)
+			}
+		})
+	}
+}
+
+func Test_isContestOver(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		end     time.Time
+		want    bool
+	}{
+		{
+			name:    "Test #1",
+			fixture: "standings_gym.html",
+			end:     time.Now().Add(time.Hour),
+			want:    true,
+		},
+		{
+			name:    "Test #2", // Phase not shown; contest has ended.
+			fixture: "error.html",
+			end:     time.Now().Add(-time.Hour),
+			want:    true,
+		},
+		{
+			name:    "Test #3", // Phase not shown; contest is running.
+			fixture: "error.html",
+			end:     time.Now().Add(time.Hour),
+			want:    false,
+		},
+		{
+			name:    "Test #4", // Phase and end time unknown.
+			fixture: "error.html",
+			end:     time.Time{},
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isContestOver(loadFixture(t, tt.fixture), tt.end); got != tt.want {
+				t.Errorf("isContestOver() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_checkRegister(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		opts    RegisterOptions
+		wantErr error
+	}{
+		{
+			name:    "Test #1",
+			fixture: "register.html",
+			opts:    RegisterOptions{},
+		},
+		{
+			name:    "Test #2",
+			fixture: "register.html",
+			opts:    RegisterOptions{Unrated: true, Team: "HCMUS-Fireflies"},
+		},
+		{
+			name:    "Test #3", // Form not present.
+			fixture: "error.html",
+			opts:    RegisterOptions{},
+			wantErr: ErrRegistrationClosed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkRegister(loadFixture(t, tt.fixture), tt.opts); err != tt.wantErr {
+				t.Errorf("checkRegister() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Team not present.
+	if err := checkRegister(loadFixture(t, "register.html"), RegisterOptions{Team: "invalid"}); err == nil {
+		t.Errorf("checkRegister() expected error for invalid team")
+	}
+}
+
+func Test_getTeamValue(t *testing.T) {
+	tests := []struct {
+		name string
+		team string
+		want string
+	}{
+		{
+			name: "Test #1",
+			team: "HCMUS-Fireflies",
+			want: "4567",
+		},
+		{
+			name: "Test #2", // Substring of a team name.
+			team: "HCMUS",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getTeamValue(loadFixture(t, "register.html"), tt.team); got != tt.want {
+				t.Errorf("getTeamValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getRegStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		arg        Args
+		want       int
+		wantErr    error
+		wantUnlink string
+	}{
+		{
+			name:       "Test #1",
+			arg:        Args{"1501", "", "contest", ""},
+			want:       RegistrationDone,
+			wantUnlink: "/contestRegistration/1501/unregister?csrf_token=0f1e2d3c4b5a",
+		},
+		{
+			name: "Test #2",
+			arg:  Args{"1500", "", "contest", ""},
+			want: RegistrationOpen,
+		},
+		{
+			name:    "Test #3",
+			arg:     Args{"1499", "", "contest", ""},
+			want:    RegistrationNotExists,
+			wantErr: ErrContestNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pd := loadFixture(t, "contests_list.html")
+			got, err := getRegStatus(pd, tt.arg)
+			if err != tt.wantErr {
+				t.Errorf("getRegStatus() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("getRegStatus() = %v, want %v", got, tt.want)
+			}
+			if got := getUnregisterLink(pd, tt.arg); got != tt.wantUnlink {
+				t.Errorf("getUnregisterLink() = %v, want %v", got, tt.wantUnlink)
+			}
+		})
+	}
+}
+
+func Test_getVirtualState(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		want    VirtualState
+	}{
+		{
+			name:    "Test #1",
+			fixture: "dashboard_virtual.html",
+			want:    VirtualState{VirtualRunning, time.Hour + time.Minute*20 + time.Second*30},
+		},
+		{
+			name:    "Test #2", // Russian locale.
+			fixture: "dashboard_virtual_ru.html",
+			want:    VirtualState{VirtualPending, time.Minute*14 + time.Second*59},
+		},
+		{
+			name:    "Test #3", // Not a virtual participant.
+			fixture: "dashboard_gym.html",
+			want:    VirtualState{VirtualNone, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getVirtualState(loadFixture(t, tt.fixture))
+			if err != nil {
+				t.Errorf("getVirtualState() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("getVirtualState() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getLanguages(t *testing.T) {
+	got, err := getLanguages(loadFixture(t, "problems_submit.html"))
+	if err != nil {
+		t.Fatalf("getLanguages() error = %v", err)
+	}
+	want := []Language{
+		{"43", "GNU GCC C11 5.1.0", "C", ".c"},
+		{"80", "Clang++20 Diagnostics", "C++", ".cpp"},
+		{"54", "GNU G++17 7.3.0", "C++", ".cpp"},
+		{"73", "GNU G++20 11.2.0 (64 bit, winlibs)", "C++", ".cpp"},
+		{"79", "C# 10, .NET SDK 6.0", "C#", ".cs"},
+		{"87", "Java 21 64bit", "Java", ".java"},
+		{"31", "Python 3.8.10", "Python", ".py"},
+		{"70", "PyPy 3.9.10 (7.3.9, 64bit)", "Python", ".py"},
+		{"34", "JavaScript V8 4.8.0", "JavaScript", ".js"},
+		{"55", "Node.js 15.8.0 (64bit)", "JavaScript", ".js"},
+		{"32", "Go 1.19.5", "Go", ".go"},
+		{"75", "Rust 1.66.0 (2021)", "Rust", ".rs"},
+		{"51", "PascalABC.NET 3.8.3", "Pascal", ".pas"},
+		{"57", "Text", "Text", ".txt"},
+		{"26", "Secret_171", "", ".secret_171"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("getLanguages() = %v, want %v", got, want)
+	}
+
+	// Submit form not present.
+	if _, err := getLanguages(loadFixture(t, "problems_interactive.html")); err != ErrSubmissionClosed {
+		t.Errorf("getLanguages() error = %v, want %v", err, ErrSubmissionClosed)
+	}
+	if _, err := getLanguages(loadFixture(t, "enter.html")); err != ErrNotLoggedIn {
+		t.Errorf("getLanguages() error = %v, want %v", err, ErrNotLoggedIn)
+	}
+}
+
+func Test_checkSubmit(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  string
+		langName string
+		want     string
+		wantErr  error
+	}{
+		{
+			name:     "Test #1",
+			fixture:  "problems_submit.html",
+			langName: "GNU G++17 7.3.0",
+			want:     "54",
+		},
+		{
+			name:     "Test #2", // Known language, not listed in the form.
+			fixture:  "problems_submit.html",
+			langName: "Python 2.7.18",
+			wantErr:  ErrLanguageNotAllowed,
+		},
+		{
+			name:     "Test #3", // Prefix of a listed language.
+			fixture:  "problems_submit.html",
+			langName: "GNU G++",
+			wantErr:  ErrInvalidLanguage,
+		},
+		{
+			name:     "Test #4",
+			fixture:  "problems_interactive.html",
+			langName: "GNU G++17 7.3.0",
+			wantErr:  ErrSubmissionClosed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := checkSubmit(loadFixture(t, tt.fixture), tt.langName)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkSubmit() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("checkSubmit() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getSubmissionDetails(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		want    SubmissionDetails
+	}{
+		{
+			name:    "Test #1",
+			fixture: "submission_details.html",
+			want: SubmissionDetails{
+				Protocol: []TestResult{
+					{
+						Test:       1,
+						Verdict:    "OK",
+						Time:       time.Millisecond * 15,
+						Memory:     4 << 10,
+						Input:      "8\n",
+						Output:     "YES",
+						Answer:     "YES\n",
+						CheckerLog: "ok answer is YES",
+					},
+					{
+						Test:       2,
+						Verdict:    "WRONG_ANSWER",
+						Time:       time.Millisecond * 30,
+						Memory:     0,
+						Input:      "5\n",
+						Output:     "YES",
+						Answer:     "NO\n",
+						CheckerLog: "wrong answer expected NO, found YES",
+					},
+				},
+			},
+		},
+		{
+			name:    "Test #2", // Russian locale.
+			fixture: "submission_details_ru.html",
+			want: SubmissionDetails{
+				Protocol: []TestResult{
+					{
+						Test:    1,
+						Verdict: "TIME_LIMIT_EXCEEDED",
+						Time:    time.Second,
+						Memory:  256 << 10,
+						Input:   "8\n",
+						Answer:  "YES\n",
+					},
+				},
+			},
+		},
+		{
+			name:    "Test #3", // Compilation error.
+			fixture: "submission_ce_ru.html",
+			want: SubmissionDetails{
+				CompilationError: "Can't compile file:\n" +
+					"program.cpp: In function 'int main()':\n" +
+					"program.cpp:1:21: error: 'x' was not declared in this scope",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getSubmissionDetails(loadFixture(t, tt.fixture))
+			if err != nil {
+				t.Errorf("getSubmissionDetails() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getSubmissionDetails() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getStatusFilter(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  Args
+		opts SubmissionsOptions
+		want map[string]string
+	}{
+		{
+			name: "Test #1", // Filters already set.
+			arg:  Args{"4", "a", "contest", ""},
+			want: nil,
+		},
+		{
+			name: "Test #2",
+			arg:  Args{"4", "", "contest", ""},
+			opts: SubmissionsOptions{Verdicts: []int{VerdictWA}, Family: "Ruby", Problem: "b"},
+			want: map[string]string{
+				"frameProblemIndex":     "B",
+				"verdictName":           "WRONG_ANSWER",
+				"programTypeForInvoker": "ruby.3",
+			},
+		},
+		{
+			name: "Test #3", // Filters not supported by the form.
+			arg:  Args{"4", "", "contest", ""},
+			opts: SubmissionsOptions{Verdicts: []int{VerdictAC, VerdictWA}, Family: "Rust"},
+			want: map[string]string{
+				"frameProblemIndex":     "anyProblem",
+				"verdictName":           "anyVerdict",
+				"programTypeForInvoker": "anyProgramTypeForInvoker",
+			},
+		},
+		{
+			name: "Test #4", // Family with many compilers listed.
+			arg:  Args{"4", "a", "contest", ""},
+			opts: SubmissionsOptions{Family: "C++"},
+			want: nil,
+		},
+		{
+			name: "Test #5", // No filters; last filters set are reset.
+			arg:  Args{"4", "", "contest", ""},
+			want: map[string]string{
+				"frameProblemIndex":     "anyProblem",
+				"verdictName":           "anyVerdict",
+				"programTypeForInvoker": "anyProgramTypeForInvoker",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getStatusFilter(loadFixture(t, "submissions.html"), tt.arg, tt.opts)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getStatusFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Form not present in the page.
+	if got := getStatusFilter(loadFixture(t, "submission_details.html"), Args{}, SubmissionsOptions{}); got != nil {
+		t.Errorf("getStatusFilter() = %v, want nil", got)
+	}
+}
+
+func Test_getProblemset(t *testing.T) {
+	want := []Problem{
+		{
+			Name:        "Watermelon",
+			Tags:        []string{"brute force", "math"},
+			Rating:      800,
+			SolveCount:  340125,
+			SolveStatus: SolveAccepted,
+			Arg:         Args{"4", "a", "contest", ""},
+		},
+		{
+			Name:        "Mysterious Present",
+			Tags:        []string{"dp", "sortings"},
+			Rating:      1700,
+			SolveCount:  12409,
+			SolveStatus: SolveRejected,
+			Arg:         Args{"4", "d", "contest", ""},
+		},
+		{
+			// Problem of a running contest.
+			Name:        "Cheat and Win",
+			SolveStatus: SolveNotAttempted,
+			Arg:         Args{"1439", "e", "contest", ""},
+		},
+	}
+
+	got, err := getProblemset(loadFixture(t, "problemset.html"))
+	if err != nil {
+		t.Fatalf("getProblemset() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("getProblemset() = %v, want %v", got, want)
+	}
+}
+
+func Test_getUser(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+		want    User
+		wantErr error
+	}{
+		{
+			name:    "Test #1",
+			fixture: "profile.html",
+			want: User{
+				Handle:       "tourist",
+				Rating:       3822,
+				MaxRating:    3979,
+				Rank:         "legendary grandmaster",
+				MaxRank:      "legendary grandmaster",
+				Contribution: 185,
+				Friends:      52151,
+				Organization: "ITMO University",
+				Country:      "Belarus",
+				City:         "Gomel",
+				Registered:   time.Date(2010, time.February, 9, 20, 30, 0, 0, time.UTC),
+				LastVisit:    time.Date(2020, time.October, 18, 12, 0, 0, 0, time.UTC),
+				Avatar:       "https://userpic.codeforces.org/422/title/50a270ed4a722867.jpg",
+			},
+		},
+		{
+			name:    "Test #2", // Russian locale, unrated and online.
+			fixture: "profile_ru.html",
+			want: User{
+				Handle:       "cp-tools",
+				Contribution: -2,
+				Friends:      1,
+				Registered:   time.Date(2020, time.June, 26, 19, 35, 0, 0, time.UTC),
+				Avatar:       "https://userpic.codeforces.org/no-title.jpg",
+			},
+		},
+		{
+			name:    "Test #3", // Error notification.
+			fixture: "error.html",
+			wantErr: ErrUserNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getUser(loadFixture(t, tt.fixture))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("getUser() error = %v, want %v", err, tt.wantErr)
+			}
+
+			// Online users were last seen now.
+			if tt.fixture == "profile_ru.html" {
+				if time.Since(got.LastVisit) > time.Minute*2 {
+					t.Errorf("getUser() LastVisit = %v, want now", got.LastVisit)
+				}
+				got.LastVisit = time.Time{}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getUser() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,7 +1,34 @@
 package codeforces
 
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Language holds details of a language that
+// solutions can be submitted in.
+type Language struct {
+	ID   string
+	Name string
+	// Family is the normalized name of the language
+	// (like "C++" or "Python"). Empty if unknown.
+	Family string
+	// Extn is the file extension of source codes
+	// in the language (like ".cpp").
+	Extn string
+}
+
 var (
 	// LanguageID represents all available languages with ids.
+	//
+	// The map is static, and may be outdated (compilers are
+	// updated often). Use GetLanguages for the current list.
+	// It serves as a fallback if the list can't be fetched.
 	LanguageID = map[string]string{
 		"Microsoft Visual C++ 2010":        "2",
 		"Delphi 7":                         "3",
@@ -56,7 +83,9 @@ var (
 	}
 
 	// LanguageExtn corresponds to file extension of
-	// given language source code.
+	// given language source code. Keys are the short names
+	// shown on the website (like "GNU C++17"); use
+	// ResolveLanguage for names of any other form.
 	LanguageExtn = map[string]string{
 		"GNU C11":               ".c",
 		"Clang++17 Diagnostics": ".cpp",
@@ -108,3 +137,251 @@ var (
 		"":                      ".txt",
 	}
 )
+
+// langFamilies maps patterns of (lowercase) compiler
+// names to language family. Order of checking matters.
+var langFamilies = []struct {
+	family string
+	rgx    *regexp.Regexp
+}{
+	{"C++", regexp.MustCompile(`g\+\+|c\+\+`)},
+	{"C#", regexp.MustCompile(`c#`)},
+	{"Q#", regexp.MustCompile(`q#`)},
+	{"F#", regexp.MustCompile(`f#`)},
+	{"C", regexp.MustCompile(`\bgcc\b|\bc11\b`)},
+	{"Python", regexp.MustCompile(`python|pypy`)},
+	{"JavaScript", regexp.MustCompile(`javascript|node\.js`)},
+	{"Java", regexp.MustCompile(`^java\b`)},
+	{"Kotlin", regexp.MustCompile(`kotlin`)},
+	{"Scala", regexp.MustCompile(`^scala\b`)},
+	{"Go", regexp.MustCompile(`^go\b`)},
+	{"Rust", regexp.MustCompile(`rust`)},
+	{"Ruby", regexp.MustCompile(`ruby`)},
+	{"PHP", regexp.MustCompile(`php`)},
+	{"Perl", regexp.MustCompile(`perl`)},
+	{"Haskell", regexp.MustCompile(`haskell`)},
+	{"OCaml", regexp.MustCompile(`ocaml`)},
+	{"D", regexp.MustCompile(`^d\b`)},
+	{"Pascal", regexp.MustCompile(`pascal|delphi|\bfpc\b`)},
+	{"Tcl", regexp.MustCompile(`tcl`)},
+	{"Ada", regexp.MustCompile(`\bada\b`)},
+	{"Cobol", regexp.MustCompile(`cobol`)},
+	{"Text", regexp.MustCompile(`^text$`)},
+}
+
+// familyExtn is the canonical file extension of each language family.
+var familyExtn = map[string]string{
+	"C++":        ".cpp",
+	"C#":         ".cs",
+	"Q#":         ".qs",
+	"F#":         ".fs",
+	"C":          ".c",
+	"Python":     ".py",
+	"JavaScript": ".js",
+	"Java":       ".java",
+	"Kotlin":     ".kt",
+	"Scala":      ".scala",
+	"Go":         ".go",
+	"Rust":       ".rs",
+	"Ruby":       ".rb",
+	"PHP":        ".php",
+	"Perl":       ".pl",
+	"Haskell":    ".hs",
+	"OCaml":      ".ml",
+	"D":          ".d",
+	"Pascal":     ".pas",
+	"Tcl":        ".tcl",
+	"Ada":        ".adb",
+	"Cobol":      ".cbl",
+	"Text":       ".txt",
+}
+
+// languageFamily returns the family of the given
+// compiler name. Returns empty string if unknown.
+func languageFamily(name string) string {
+	name = strings.ToLower(clean(name))
+	for _, lf := range langFamilies {
+		if lf.rgx.MatchString(name) {
+			return lf.family
+		}
+	}
+	return ""
+}
+
+// languageExtn returns the file extension of source
+// codes in the given language, of the given family.
+func languageExtn(name, family string) string {
+	if extn, ok := familyExtn[family]; ok {
+		return extn
+	}
+	if extn, ok := LanguageExtn[clean(name)]; ok {
+		return extn
+	}
+	return LanguageExtn[""]
+}
+
+// langTokens splits the (lowercase) compiler name into tokens,
+// spelling C++ compilers uniformly (g++17 is the same as c++17).
+func langTokens(name string) []string {
+	name = strings.ReplaceAll(strings.ToLower(name), "g++", "c++")
+	return strings.FieldsFunc(name, func(r rune) bool {
+		return !strings.ContainsRune("+#._", r) &&
+			!('a' <= r && r <= 'z') && !('0' <= r && r <= '9')
+	})
+}
+
+// langSimilarity returns the number of tokens of x found in y.
+// Tokens match if equal, or if one is a version prefix of the
+// other (like "3" and "3.9.1").
+func langSimilarity(x, y []string) int {
+	isPrefix := func(a, b string) bool {
+		return strings.HasPrefix(b, a) && (len(a) == len(b) || b[len(a)] == '.')
+	}
+
+	score := 0
+	for _, a := range x {
+		for _, b := range y {
+			if isPrefix(a, b) || isPrefix(b, a) {
+				score++
+				break
+			}
+		}
+	}
+	return score
+}
+
+// ResolveLanguage resolves the given compiler name (in any form,
+// like "GNU C++17" of Submission.Language or "GNU G++17 7.3.0" of
+// LanguageID) to its language family and file extension. ID and
+// Name of the returned value are of the closest language among
+// languages (see GetLanguages), to submit solutions in. The static
+// map LanguageID is used instead if languages is nil.
+//
+// ID and Name are empty if no language of the same family is
+// found. For instance, to save the source code of a submission:
+//
+//	extn := codeforces.ResolveLanguage(sub.Language, nil).Extn
+func ResolveLanguage(name string, languages []Language) Language {
+	family := languageFamily(name)
+	resolved := Language{
+		Family: family,
+		Extn:   languageExtn(name, family),
+	}
+
+	if languages == nil {
+		languages = staticLanguages()
+	}
+
+	name = clean(name)
+	tokens, score, size, best := langTokens(name), -1, 0, 0
+	for _, lang := range languages {
+		if lang.Name == name {
+			// Exact match; nothing closer is possible.
+			resolved.ID, resolved.Name = lang.ID, lang.Name
+			return resolved
+		}
+		if family == "" || languageFamily(lang.Name) != family {
+			continue
+		}
+
+		// In case of ties, prefer languages with lesser details
+		// (the common variant), and then newer ones (higher ids).
+		id, _ := strconv.Atoi(lang.ID)
+		langTkns := langTokens(lang.Name)
+		cur := langSimilarity(tokens, langTkns)
+		if cur > score || (cur == score && (len(langTkns) < size ||
+			(len(langTkns) == size && id > best))) {
+			resolved.ID, resolved.Name = lang.ID, lang.Name
+			score, size, best = cur, len(langTkns), id
+		}
+	}
+	return resolved
+}
+
+// newLanguage returns the language with given id and name.
+func newLanguage(id, name string) Language {
+	family := languageFamily(name)
+	return Language{
+		ID:     id,
+		Name:   name,
+		Family: family,
+		Extn:   languageExtn(name, family),
+	}
+}
+
+// staticLanguages returns languages in map LanguageID.
+func staticLanguages() []Language {
+	var languages []Language
+	for name, id := range LanguageID {
+		languages = append(languages, newLanguage(id, name))
+	}
+
+	sort.Slice(languages, func(i, j int) bool {
+		x, _ := strconv.Atoi(languages[i].ID)
+		y, _ := strconv.Atoi(languages[j].ID)
+		return x < y
+	})
+	return languages
+}
+
+func getLanguages(pd *goquery.Document) ([]Language, error) {
+	selc := pd.Find(`select[name="programTypeId"]`)
+	if selc.Length() == 0 {
+		// Submit form isn't present.
+		if getCurrentUser(pd) == "" {
+			return nil, ErrNotLoggedIn
+		}
+		return nil, ErrSubmissionClosed
+	}
+
+	var languages []Language
+	selc.Find(`option[value]`).Each(func(_ int, option *goquery.Selection) {
+		id := clean(option.AttrOr("value", ""))
+		if id == "" {
+			return
+		}
+
+		languages = append(languages, newLanguage(id, clean(option.Text())))
+	})
+	return languages, nil
+}
+
+// GetLanguages returns the languages solutions can be submitted
+// in, as listed in the submit form of the problem. If the form
+// isn't present, ErrNotLoggedIn (or ErrSubmissionClosed, if
+// logged in) is returned; the static map LanguageID may be used
+// as a fallback then.
+func (arg Args) GetLanguages() ([]Language, error) {
+	return DefaultClient.GetLanguages(arg)
+}
+
+// GetLanguagesContext is the same as GetLanguages, using the given context.
+func (arg Args) GetLanguagesContext(ctx context.Context) ([]Language, error) {
+	return DefaultClient.GetLanguagesContext(ctx, arg)
+}
+
+// GetLanguages returns the languages allowed in the problem.
+// See Args.GetLanguages for more details.
+func (c *Client) GetLanguages(arg Args) ([]Language, error) {
+	return c.GetLanguagesContext(context.Background(), arg)
+}
+
+// GetLanguagesContext is the same as GetLanguages, using the given context.
+func (c *Client) GetLanguagesContext(ctx context.Context, arg Args) ([]Language, error) {
+	// problem not specified, return invalid
+	if arg.Problem == "" {
+		return nil, ErrInvalidSpecifier
+	}
+
+	link, err := c.ProblemsPage(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	pd, err := c.fetch(ctx, link, `#footer`)
+	if err != nil {
+		return nil, err
+	}
+
+	return getLanguages(pd)
+}
@@ -0,0 +1,137 @@
+package codeforces
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_languageFamily(t *testing.T) {
+	tests := []struct {
+		name string
+		lang string
+		want string
+	}{
+		{"Test #1", "GNU G++17 9.2.0 (64 bit, msys 2)", "C++"},
+		{"Test #2", "Microsoft Visual C++ 2017", "C++"},
+		{"Test #3", "GNU GCC C11 5.1.0", "C"},
+		{"Test #4", "C# Mono 6.8", "C#"},
+		{"Test #5", "Microsoft Q#", "Q#"},
+		{"Test #6", "PyPy 2.7 (7.3.0)", "Python"},
+		{"Test #7", "Java 1.8.0_241", "Java"},
+		{"Test #8", "JavaScript V8 4.8.0", "JavaScript"},
+		{"Test #9", "D DMD32 v2.091.0", "D"},
+		{"Test #10", "Delphi 7", "Pascal"},
+		{"Test #11", "Free Pascal 3.0.2", "Pascal"},
+		{"Test #12", "Haskell GHC 8.10.1", "Haskell"},
+		{"Test #13", "Befunge", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := languageFamily(tt.lang); got != tt.want {
+				t.Errorf("languageFamily() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveLanguage(t *testing.T) {
+	current := []Language{
+		newLanguage("43", "GNU GCC C11 5.1.0"),
+		newLanguage("54", "GNU G++17 7.3.0"),
+		newLanguage("73", "GNU G++20 11.2.0 (64 bit, winlibs)"),
+		newLanguage("31", "Python 3.8.10"),
+		newLanguage("70", "PyPy 3.9.10 (7.3.9, 64bit)"),
+	}
+
+	tests := []struct {
+		name      string
+		lang      string
+		languages []Language
+		want      Language
+	}{
+		{
+			name: "Test #1",
+			lang: "GNU C++17",
+			want: Language{"54", "GNU G++17 7.3.0", "C++", ".cpp"},
+		},
+		{
+			name: "Test #2",
+			lang: "GNU C++17 (64)",
+			want: Language{"61", "GNU G++17 9.2.0 (64 bit, msys 2)", "C++", ".cpp"},
+		},
+		{
+			name: "Test #3",
+			lang: "MS C++ 2017",
+			want: Language{"59", "Microsoft Visual C++ 2017", "C++", ".cpp"},
+		},
+		{
+			name: "Test #4",
+			lang: "PyPy 3",
+			want: Language{"41", "PyPy 3.7 (7.3.0)", "Python", ".py"},
+		},
+		{
+			name: "Test #5",
+			lang: "Python 2",
+			want: Language{"7", "Python 2.7.18", "Python", ".py"},
+		},
+		{
+			name: "Test #6",
+			lang: "Befunge",
+			want: Language{"18", "Befunge", "", ".bf"},
+		},
+		{
+			name: "Test #7", // Unknown language.
+			lang: "Brainfuck",
+			want: Language{"", "", "", ".txt"},
+		},
+		{
+			name:      "Test #8",
+			lang:      "GNU C++20 (64)",
+			languages: current,
+			want:      Language{"73", "GNU G++20 11.2.0 (64 bit, winlibs)", "C++", ".cpp"},
+		},
+		{
+			name:      "Test #9", // Compiler not available anymore.
+			lang:      "GNU C++11",
+			languages: current,
+			want:      Language{"54", "GNU G++17 7.3.0", "C++", ".cpp"},
+		},
+		{
+			name:      "Test #10",
+			lang:      "Python 3",
+			languages: current,
+			want:      Language{"31", "Python 3.8.10", "Python", ".py"},
+		},
+		{
+			name:      "Test #11",
+			lang:      "GNU C11",
+			languages: current,
+			want:      Language{"43", "GNU GCC C11 5.1.0", "C", ".c"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveLanguage(tt.lang, tt.languages); got != tt.want {
+				t.Errorf("ResolveLanguage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArgs_GetLanguages(t *testing.T) {
+	skipOffline(t)
+	time.Sleep(time.Second * 10)
+
+	got, err := Args{"4", "a", ClassContest, ""}.GetLanguages()
+	if err != nil {
+		t.Fatalf("Args.GetLanguages() error = %v", err)
+	}
+
+	found := false
+	for _, lang := range got {
+		found = found || lang.Family == "C++"
+	}
+	if !found {
+		t.Errorf("Args.GetLanguages() = %v, no C++ language found", got)
+	}
+}
@@ -1,13 +1,14 @@
 package codeforces
 
 import (
+	"context"
 	"fmt"
-
-	"github.com/go-rod/rod"
+	"net/url"
+	"strings"
 )
 
 // CountdownPage returns link to countdown in contest.
-func (arg Args) CountdownPage() (link string, err error) {
+func (c *Client) CountdownPage(arg Args) (link string, err error) {
 	if arg.Contest == "" {
 		return "", ErrInvalidSpecifier
 	}
@@ -18,10 +19,10 @@ func (arg Args) CountdownPage() (link string, err error) {
 			return "", ErrInvalidSpecifier
 		}
 
-		link = fmt.Sprintf("%v/group/%v/contest/%v/countdown", hostURL, arg.Group, arg.Contest)
+		link = fmt.Sprintf("%v/group/%v/contest/%v/countdown", c.Host(), arg.Group, arg.Contest)
 
 	case ClassContest, ClassGym:
-		link = fmt.Sprintf("%v/%v/%v/countdown", hostURL, arg.Class, arg.Contest)
+		link = fmt.Sprintf("%v/%v/%v/countdown", c.Host(), arg.Class, arg.Contest)
 
 	default:
 		return "", ErrInvalidSpecifier
@@ -31,7 +32,7 @@ func (arg Args) CountdownPage() (link string, err error) {
 }
 
 // ContestsPage returns link to contests page of group/gym/contest.
-func (arg Args) ContestsPage() (link string, err error) {
+func (c *Client) ContestsPage(arg Args) (link string, err error) {
 
 	switch arg.Class {
 	case ClassGroup:
@@ -41,23 +42,23 @@ func (arg Args) ContestsPage() (link string, err error) {
 
 		// details of individual contest can't be parsed.
 		// fallback to parsing all contests in group.
-		link = fmt.Sprintf("%v/group/%v/contests?complete=true", hostURL, arg.Group)
+		link = fmt.Sprintf("%v/group/%v/contests?complete=true", c.Host(), arg.Group)
 
 	case ClassContest:
 		if arg.Contest == "" {
-			link = fmt.Sprintf("%v/contests?complete=true", hostURL)
+			link = fmt.Sprintf("%v/contests?complete=true", c.Host())
 			return
 		}
 
-		link = fmt.Sprintf("%v/contests/%v", hostURL, arg.Contest)
+		link = fmt.Sprintf("%v/contests/%v", c.Host(), arg.Contest)
 
 	case ClassGym:
 		if arg.Contest == "" {
-			link = fmt.Sprintf("%v/gyms?complete=true", hostURL)
+			link = fmt.Sprintf("%v/gyms?complete=true", c.Host())
 			return
 		}
 
-		link = fmt.Sprintf("%v/contests/%v", hostURL, arg.Contest)
+		link = fmt.Sprintf("%v/contests/%v", c.Host(), arg.Contest)
 
 	default:
 		return "", ErrInvalidSpecifier
@@ -67,7 +68,7 @@ func (arg Args) ContestsPage() (link string, err error) {
 }
 
 // DashboardPage returns link to dashboard of contest.
-func (arg Args) DashboardPage() (link string, err error) {
+func (c *Client) DashboardPage(arg Args) (link string, err error) {
 	if arg.Contest == "" {
 		return "", ErrInvalidSpecifier
 	}
@@ -78,10 +79,10 @@ func (arg Args) DashboardPage() (link string, err error) {
 			return "", ErrInvalidSpecifier
 		}
 
-		link = fmt.Sprintf("%v/group/%v/contest/%v", hostURL, arg.Group, arg.Contest)
+		link = fmt.Sprintf("%v/group/%v/contest/%v", c.Host(), arg.Group, arg.Contest)
 
 	case ClassContest, ClassGym:
-		link = fmt.Sprintf("%v/%v/%v", hostURL, arg.Class, arg.Contest)
+		link = fmt.Sprintf("%v/%v/%v", c.Host(), arg.Class, arg.Contest)
 
 	default:
 		return "", ErrInvalidSpecifier
@@ -92,19 +93,40 @@ func (arg Args) DashboardPage() (link string, err error) {
 
 // RegisterPage returns link to registration (not virtual contest registration)
 // in contest.
-func (arg Args) RegisterPage() (link string, err error) {
+func (c *Client) RegisterPage(arg Args) (link string, err error) {
 	if arg.Contest == "" || arg.Class != ClassContest {
 		return "", ErrInvalidSpecifier
 	}
 
 	// gyms/groups don't support registration, do they!?
 	link = fmt.Sprintf("%v/contestRegistration/%v",
-		hostURL, arg.Contest)
+		c.Host(), arg.Contest)
+	return
+}
+
+// VirtualPage returns link to virtual contest registration
+// in contest/gym.
+func (c *Client) VirtualPage(arg Args) (link string, err error) {
+	if arg.Contest == "" {
+		return "", ErrInvalidSpecifier
+	}
+
+	switch arg.Class {
+	case ClassContest:
+		link = fmt.Sprintf("%v/contestRegistration/%v/virtual/true", c.Host(), arg.Contest)
+
+	case ClassGym:
+		link = fmt.Sprintf("%v/gymRegistration/%v/virtual/true", c.Host(), arg.Contest)
+
+	default:
+		return "", ErrInvalidSpecifier
+	}
+
 	return
 }
 
 // ProblemsPage returns link to problem(s) page in contest.
-func (arg Args) ProblemsPage() (link string, err error) {
+func (c *Client) ProblemsPage(arg Args) (link string, err error) {
 	if arg.Contest == "" {
 		return "", ErrInvalidSpecifier
 	}
@@ -116,16 +138,16 @@ func (arg Args) ProblemsPage() (link string, err error) {
 		}
 
 		if arg.Problem == "" {
-			link = fmt.Sprintf("%v/group/%v/contest/%v/problems", hostURL, arg.Group, arg.Contest)
+			link = fmt.Sprintf("%v/group/%v/contest/%v/problems", c.Host(), arg.Group, arg.Contest)
 		} else {
-			link = fmt.Sprintf("%v/group/%v/contest/%v/problem/%v", hostURL, arg.Group, arg.Contest, arg.Problem)
+			link = fmt.Sprintf("%v/group/%v/contest/%v/problem/%v", c.Host(), arg.Group, arg.Contest, arg.Problem)
 		}
 
 	case ClassContest, ClassGym:
 		if arg.Problem == "" {
-			link = fmt.Sprintf("%v/%v/%v/problems", hostURL, arg.Class, arg.Contest)
+			link = fmt.Sprintf("%v/%v/%v/problems", c.Host(), arg.Class, arg.Contest)
 		} else {
-			link = fmt.Sprintf("%v/%v/%v/problem/%v", hostURL, arg.Class, arg.Contest, arg.Problem)
+			link = fmt.Sprintf("%v/%v/%v/problem/%v", c.Host(), arg.Class, arg.Contest, arg.Problem)
 		}
 
 	default:
@@ -136,27 +158,21 @@ func (arg Args) ProblemsPage() (link string, err error) {
 }
 
 // SubmissionsPage returns link to user submissions page.
-func (arg Args) SubmissionsPage(handle string) (link string, err error) {
+func (c *Client) SubmissionsPage(arg Args, handle string) (link string, err error) {
+	return c.submissionsPage(context.Background(), arg, handle)
+}
+
+func (c *Client) submissionsPage(ctx context.Context, arg Args, handle string) (link string, err error) {
 	// Contest not specified.
 	if arg.Contest == "" {
 		if handle == "" {
 			// Extract handle from homepage.
-			p, err := loadPage(hostURL)
-			if err != nil {
-				return "", ErrInvalidSpecifier
-			}
-			defer p.Close()
-			p.WaitLoad()
-
-			var elm *rod.Element
-			if elm = p.MustElements(`#header a[href^="/profile/"]`).First(); elm == nil {
+			if handle, err = c.CurrentUserContext(ctx); err != nil || handle == "" {
 				return "", ErrInvalidSpecifier
 			}
-
-			handle = elm.MustText()
 		}
 
-		link = fmt.Sprintf("%v/submissions/%v", hostURL, handle)
+		link = fmt.Sprintf("%v/submissions/%v", c.Host(), handle)
 		return
 	}
 
@@ -167,13 +183,13 @@ func (arg Args) SubmissionsPage(handle string) (link string, err error) {
 			return "", ErrInvalidSpecifier
 		}
 
-		link = fmt.Sprintf("%v/group/%v/contest/%v/my", hostURL, arg.Group, arg.Contest)
+		link = fmt.Sprintf("%v/group/%v/contest/%v/my", c.Host(), arg.Group, arg.Contest)
 
 	case ClassContest, ClassGym:
 		if handle == "" {
-			link = fmt.Sprintf("%v/%v/%v/my", hostURL, arg.Class, arg.Contest)
+			link = fmt.Sprintf("%v/%v/%v/my", c.Host(), arg.Class, arg.Contest)
 		} else {
-			link = fmt.Sprintf("%v/submissions/%v/%v/%v", hostURL, handle, arg.Class, arg.Contest)
+			link = fmt.Sprintf("%v/submissions/%v/%v/%v", c.Host(), handle, arg.Class, arg.Contest)
 		}
 
 	default:
@@ -183,18 +199,152 @@ func (arg Args) SubmissionsPage(handle string) (link string, err error) {
 	return
 }
 
+// StatusPage returns link to the status (all submissions)
+// of contest, or problem in contest. If contest is not
+// specified, returns link to the status of problemset
+// (an empty class is taken as ClassContest).
+func (c *Client) StatusPage(arg Args) (link string, err error) {
+	if arg.Contest == "" {
+		if arg.Class != ClassContest && arg.Class != "" {
+			return "", ErrInvalidSpecifier
+		}
+
+		link = fmt.Sprintf("%v/problemset/status", c.Host())
+		return
+	}
+
+	switch arg.Class {
+	case ClassGroup:
+		if arg.Group == "" {
+			return "", ErrInvalidSpecifier
+		}
+
+		link = fmt.Sprintf("%v/group/%v/contest/%v/status", c.Host(), arg.Group, arg.Contest)
+
+	case ClassContest, ClassGym:
+		link = fmt.Sprintf("%v/%v/%v/status", c.Host(), arg.Class, arg.Contest)
+
+	default:
+		return "", ErrInvalidSpecifier
+	}
+
+	if arg.Problem != "" {
+		link += fmt.Sprintf("/%v", arg.Problem)
+	}
+	return
+}
+
+// StandingsPage returns link to standings of contest,
+// with the given filters applied.
+func (c *Client) StandingsPage(arg Args, opts StandingsOptions) (link string, err error) {
+	return c.standingsPage(arg, opts, 1)
+}
+
+func (c *Client) standingsPage(arg Args, opts StandingsOptions, page int) (link string, err error) {
+	if arg.Contest == "" || (opts.FriendsOnly && opts.Room != "") {
+		return "", ErrInvalidSpecifier
+	}
+
+	switch arg.Class {
+	case ClassGroup:
+		if arg.Group == "" || opts.Room != "" {
+			return "", ErrInvalidSpecifier
+		}
+
+		link = fmt.Sprintf("%v/group/%v/contest/%v/standings", c.Host(), arg.Group, arg.Contest)
+
+	case ClassContest:
+		if opts.Room != "" {
+			// Rooms aren't paginated.
+			link = fmt.Sprintf("%v/contest/%v/room/%v", c.Host(), arg.Contest, opts.Room)
+			page = 1
+			break
+		}
+
+		link = fmt.Sprintf("%v/contest/%v/standings", c.Host(), arg.Contest)
+
+	case ClassGym:
+		if opts.Room != "" {
+			return "", ErrInvalidSpecifier
+		}
+
+		link = fmt.Sprintf("%v/gym/%v/standings", c.Host(), arg.Contest)
+
+	default:
+		return "", ErrInvalidSpecifier
+	}
+
+	if opts.FriendsOnly {
+		link += "/friends/true"
+	}
+	if page > 1 {
+		link += fmt.Sprintf("/page/%v", page)
+	}
+	if opts.Unofficial {
+		link += "?showUnofficial=true"
+	}
+
+	return
+}
+
+// ProblemsetPage returns link to problemset, with the given
+// filters (except HideSolved) applied.
+func (c *Client) ProblemsetPage(filter ProblemsetFilter) (link string, err error) {
+	return c.problemsetPage(filter, 1)
+}
+
+func (c *Client) problemsetPage(filter ProblemsetFilter, page int) (link string, err error) {
+	if filter.MinRating < 0 || filter.MaxRating < 0 ||
+		(filter.MaxRating != 0 && filter.MinRating > filter.MaxRating) {
+		return "", ErrInvalidSpecifier
+	}
+
+	link = fmt.Sprintf("%v/problemset", c.Host())
+	if page > 1 {
+		link += fmt.Sprintf("/page/%v", page)
+	}
+
+	var tags []string
+	for _, tag := range filter.Tags {
+		tags = append(tags, url.QueryEscape(tag))
+	}
+	// Rating range is set as a tag too.
+	if filter.MinRating != 0 || filter.MaxRating != 0 {
+		maxRating := filter.MaxRating
+		if maxRating == 0 {
+			maxRating = maxProblemRating
+		}
+		tags = append(tags, fmt.Sprintf("%v-%v", filter.MinRating, maxRating))
+	}
+	if len(tags) != 0 {
+		link += "?tags=" + strings.Join(tags, ",")
+	}
+
+	return
+}
+
+// ProfilePage returns link to profile of the user.
+func (c *Client) ProfilePage(handle string) (link string, err error) {
+	if handle == "" {
+		return "", ErrInvalidSpecifier
+	}
+
+	link = fmt.Sprintf("%v/profile/%v", c.Host(), url.PathEscape(handle))
+	return
+}
+
 // SourceCodePage returns link to solution submission code.
-func (sub Submission) SourceCodePage() (link string, err error) {
+func (c *Client) SourceCodePage(sub Submission) (link string, err error) {
 	if sub.ID == "" || sub.Arg.Contest == "" {
 		return "", ErrInvalidSpecifier
 	}
 
 	switch sub.Arg.Class {
 	case ClassGroup:
-		link = fmt.Sprintf("%v/group/%v/contest/%v/submission/%v", hostURL, sub.Arg.Group, sub.Arg.Contest, sub.ID)
+		link = fmt.Sprintf("%v/group/%v/contest/%v/submission/%v", c.Host(), sub.Arg.Group, sub.Arg.Contest, sub.ID)
 
 	case ClassContest, ClassGym:
-		link = fmt.Sprintf("%v/%v/%v/submission/%v", hostURL, sub.Arg.Class, sub.Arg.Contest, sub.ID)
+		link = fmt.Sprintf("%v/%v/%v/submission/%v", c.Host(), sub.Arg.Class, sub.Arg.Contest, sub.ID)
 
 	default:
 		return "", ErrInvalidSpecifier
@@ -202,3 +352,75 @@ func (sub Submission) SourceCodePage() (link string, err error) {
 
 	return
 }
+
+// CountdownPage returns link to countdown in contest.
+// Uses DefaultClient; see Client.CountdownPage.
+func (arg Args) CountdownPage() (string, error) {
+	return DefaultClient.CountdownPage(arg)
+}
+
+// ContestsPage returns link to contests page of group/gym/contest.
+// Uses DefaultClient; see Client.ContestsPage.
+func (arg Args) ContestsPage() (string, error) {
+	return DefaultClient.ContestsPage(arg)
+}
+
+// DashboardPage returns link to dashboard of contest.
+// Uses DefaultClient; see Client.DashboardPage.
+func (arg Args) DashboardPage() (string, error) {
+	return DefaultClient.DashboardPage(arg)
+}
+
+// RegisterPage returns link to registration in contest.
+// Uses DefaultClient; see Client.RegisterPage.
+func (arg Args) RegisterPage() (string, error) {
+	return DefaultClient.RegisterPage(arg)
+}
+
+// VirtualPage returns link to virtual contest registration.
+// Uses DefaultClient; see Client.VirtualPage.
+func (arg Args) VirtualPage() (string, error) {
+	return DefaultClient.VirtualPage(arg)
+}
+
+// ProblemsPage returns link to problem(s) page in contest.
+// Uses DefaultClient; see Client.ProblemsPage.
+func (arg Args) ProblemsPage() (string, error) {
+	return DefaultClient.ProblemsPage(arg)
+}
+
+// SubmissionsPage returns link to user submissions page.
+// Uses DefaultClient; see Client.SubmissionsPage.
+func (arg Args) SubmissionsPage(handle string) (string, error) {
+	return DefaultClient.SubmissionsPage(arg, handle)
+}
+
+// StatusPage returns link to the status of contest/problemset.
+// Uses DefaultClient; see Client.StatusPage.
+func (arg Args) StatusPage() (string, error) {
+	return DefaultClient.StatusPage(arg)
+}
+
+// StandingsPage returns link to standings of contest.
+// Uses DefaultClient; see Client.StandingsPage.
+func (arg Args) StandingsPage(opts StandingsOptions) (string, error) {
+	return DefaultClient.StandingsPage(arg, opts)
+}
+
+// ProblemsetPage returns link to problemset.
+// Uses DefaultClient; see Client.ProblemsetPage.
+func ProblemsetPage(filter ProblemsetFilter) (string, error) {
+	return DefaultClient.ProblemsetPage(filter)
+}
+
+// ProfilePage returns link to profile of the user.
+// Uses DefaultClient; see Client.ProfilePage.
+func ProfilePage(handle string) (string, error) {
+	return DefaultClient.ProfilePage(handle)
+}
+
+// SourceCodePage returns link to solution submission code.
+// Uses DefaultClient; see Client.SourceCodePage.
+func (sub Submission) SourceCodePage() (string, error) {
+	return DefaultClient.SourceCodePage(sub)
+}
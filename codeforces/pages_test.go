@@ -226,6 +226,122 @@ func TestArgs_registerPage(t *testing.T) {
 	}
 }
 
+func TestArgs_virtualPage(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     Args
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "Test #1",
+			arg:     Args{"1500", "", "contest", ""},
+			want:    "https://codeforces.com/contestRegistration/1500/virtual/true",
+			wantErr: false,
+		},
+		{
+			name:    "Test #2",
+			arg:     Args{"100499", "", "gym", ""},
+			want:    "https://codeforces.com/gymRegistration/100499/virtual/true",
+			wantErr: false,
+		},
+		{
+			name:    "Test #3",
+			arg:     Args{"277493", "", "group", "MEqF8b6wBT"},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name:    "Test #4",
+			arg:     Args{},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.arg.VirtualPage()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Args.virtualPage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Args.virtualPage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArgs_statusPage(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     Args
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "Test #1",
+			arg:     Args{"1500", "", "contest", ""},
+			want:    "https://codeforces.com/contest/1500/status",
+			wantErr: false,
+		},
+		{
+			name:    "Test #2",
+			arg:     Args{"4", "a", "contest", ""},
+			want:    "https://codeforces.com/contest/4/status/a",
+			wantErr: false,
+		},
+		{
+			name:    "Test #3",
+			arg:     Args{"100499", "b", "gym", ""},
+			want:    "https://codeforces.com/gym/100499/status/b",
+			wantErr: false,
+		},
+		{
+			name:    "Test #4",
+			arg:     Args{"277493", "", "group", "MEqF8b6wBT"},
+			want:    "https://codeforces.com/group/MEqF8b6wBT/contest/277493/status",
+			wantErr: false,
+		},
+		{
+			name:    "Test #5",
+			arg:     Args{"", "", "contest", ""},
+			want:    "https://codeforces.com/problemset/status",
+			wantErr: false,
+		},
+		{
+			name:    "Test #6",
+			arg:     Args{"", "", "group", "MEqF8b6wBT"},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name:    "Test #7", // Same as Parse("").
+			arg:     Args{},
+			want:    "https://codeforces.com/problemset/status",
+			wantErr: false,
+		},
+		{
+			name:    "Test #8",
+			arg:     Args{"", "", "gym", ""},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.arg.StatusPage()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Args.statusPage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Args.statusPage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestArgs_problemsPage(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -383,6 +499,11 @@ func TestArgs_submissionsPage(t *testing.T) {
 				t.SkipNow()
 			}
 
+			if tt.name == "Test #9" {
+				// Handle is extracted from logged in session.
+				skipOffline(t)
+			}
+
 			got, err := tt.arg.SubmissionsPage(tt.args.handle)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Args.submissionsPage() error = %v, wantErr %v", err, tt.wantErr)
@@ -395,6 +516,182 @@ func TestArgs_submissionsPage(t *testing.T) {
 	}
 }
 
+func TestArgs_standingsPage(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     Args
+		opts    StandingsOptions
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "Test #1",
+			arg:     Args{"1500", "", "contest", ""},
+			opts:    StandingsOptions{},
+			want:    "https://codeforces.com/contest/1500/standings",
+			wantErr: false,
+		},
+		{
+			name:    "Test #2",
+			arg:     Args{"1500", "a", "contest", ""},
+			opts:    StandingsOptions{Unofficial: true, FriendsOnly: true},
+			want:    "https://codeforces.com/contest/1500/standings/friends/true?showUnofficial=true",
+			wantErr: false,
+		},
+		{
+			name:    "Test #3",
+			arg:     Args{"1500", "", "contest", ""},
+			opts:    StandingsOptions{Room: "12"},
+			want:    "https://codeforces.com/contest/1500/room/12",
+			wantErr: false,
+		},
+		{
+			name:    "Test #4",
+			arg:     Args{"100499", "", "gym", ""},
+			opts:    StandingsOptions{Unofficial: true},
+			want:    "https://codeforces.com/gym/100499/standings?showUnofficial=true",
+			wantErr: false,
+		},
+		{
+			name:    "Test #5",
+			arg:     Args{"277493", "", "group", "MEqF8b6wBT"},
+			opts:    StandingsOptions{},
+			want:    "https://codeforces.com/group/MEqF8b6wBT/contest/277493/standings",
+			wantErr: false,
+		},
+		{
+			name:    "Test #6", // Rooms exist only in contests.
+			arg:     Args{"100499", "", "gym", ""},
+			opts:    StandingsOptions{Room: "12"},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name:    "Test #7",
+			arg:     Args{"1500", "", "contest", ""},
+			opts:    StandingsOptions{FriendsOnly: true, Room: "12"},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name:    "Test #8",
+			arg:     Args{"", "", "contest", ""},
+			opts:    StandingsOptions{},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.arg.StandingsPage(tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Args.standingsPage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Args.standingsPage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Pages after the first.
+	want := "https://codeforces.com/contest/1500/standings/page/3?showUnofficial=true"
+	if got, _ := DefaultClient.standingsPage(Args{"1500", "", "contest", ""}, StandingsOptions{Unofficial: true}, 3); got != want {
+		t.Errorf("Args.standingsPage() = %v, want %v", got, want)
+	}
+}
+
+func TestProblemsetPage(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  ProblemsetFilter
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "Test #1",
+			filter:  ProblemsetFilter{},
+			want:    "https://codeforces.com/problemset",
+			wantErr: false,
+		},
+		{
+			name:    "Test #2",
+			filter:  ProblemsetFilter{Tags: []string{"dp", "data structures"}, HideSolved: true},
+			want:    "https://codeforces.com/problemset?tags=dp,data+structures",
+			wantErr: false,
+		},
+		{
+			name:    "Test #3",
+			filter:  ProblemsetFilter{Tags: []string{"math"}, MinRating: 1500, MaxRating: 2000},
+			want:    "https://codeforces.com/problemset?tags=math,1500-2000",
+			wantErr: false,
+		},
+		{
+			name:    "Test #4",
+			filter:  ProblemsetFilter{MinRating: 2400},
+			want:    "https://codeforces.com/problemset?tags=2400-3500",
+			wantErr: false,
+		},
+		{
+			name:    "Test #5",
+			filter:  ProblemsetFilter{MinRating: 2000, MaxRating: 1500},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ProblemsetPage(tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProblemsetPage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ProblemsetPage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	want := "https://codeforces.com/problemset/page/3?tags=greedy"
+	if got, _ := DefaultClient.problemsetPage(ProblemsetFilter{Tags: []string{"greedy"}}, 3); got != want {
+		t.Errorf("ProblemsetPage() = %v, want %v", got, want)
+	}
+}
+
+func TestProfilePage(t *testing.T) {
+	tests := []struct {
+		name    string
+		handle  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "Test #1",
+			handle:  "tourist",
+			want:    "https://codeforces.com/profile/tourist",
+			wantErr: false,
+		},
+		{
+			name:    "Test #2",
+			handle:  "",
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ProfilePage(tt.handle)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProfilePage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ProfilePage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestSubmission_sourceCodePage(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -440,3 +737,51 @@ func TestSubmission_sourceCodePage(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_mirrorPages(t *testing.T) {
+	tests := []struct {
+		name string
+		host string
+		page func(c *Client) (string, error)
+		want string
+	}{
+		{
+			name: "Test #1",
+			host: HostM1,
+			page: func(c *Client) (string, error) { return c.ProblemsPage(Args{"4", "a", "contest", ""}) },
+			want: "https://m1.codeforces.com/contest/4/problem/a",
+		},
+		{
+			name: "Test #2",
+			host: HostML,
+			page: func(c *Client) (string, error) { return c.ContestsPage(Args{"", "", "gym", ""}) },
+			want: "https://codeforces.ml/gyms?complete=true",
+		},
+		{
+			name: "Test #3",
+			host: HostM3,
+			page: func(c *Client) (string, error) { return c.SubmissionsPage(Args{"4", "", "contest", ""}, "cp-tools") },
+			want: "https://m3.codeforces.com/submissions/cp-tools/contest/4",
+		},
+		{
+			name: "Test #4",
+			host: HostM2,
+			page: func(c *Client) (string, error) {
+				return c.SourceCodePage(Submission{ID: "95913201", Arg: Args{"207982", "", "group", "7rY4CfQSjd"}})
+			},
+			want: "https://m2.codeforces.com/group/7rY4CfQSjd/contest/207982/submission/95913201",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.page(&Client{host: tt.host})
+			if err != nil {
+				t.Errorf("Client page error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Client page = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
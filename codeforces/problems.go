@@ -1,12 +1,23 @@
 package codeforces
 
 import (
+	"context"
 	"fmt"
+	"html"
+	"io/ioutil"
+	"net/http"
+	"net/url"
 	"os"
-	"regexp"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/cp-tools/cpt-lib/v2/util"
+
 	"github.com/PuerkitoBio/goquery"
+	"github.com/go-rod/rod"
 )
 
 type (
@@ -16,14 +27,52 @@ type (
 		Output string
 	}
 
+	// Section holds a section of the problem statement, as
+	// plain text and as html. TeX is preserved (in both) as
+	// '$...$' for inline and '$$...$$' for display formulas.
+	Section struct {
+		Text string
+		HTML string
+	}
+
+	// Statement holds all sections of the problem statement.
+	// Sections not present in the problem are left empty.
+	Statement struct {
+		Legend      Section
+		Input       Section
+		Output      Section
+		Interaction Section
+		Scoring     Section
+		Notes       Section
+	}
+
+	// ProblemsOptions holds optional settings of GetProblems.
+	ProblemsOptions struct {
+		// AssetsDir, if set, is the directory to download all
+		// images and attachments, referenced by the statements,
+		// to. Assets of each problem are saved in a directory
+		// named after the problem, and the statement html is
+		// rewritten to point to the downloaded copies, relative
+		// to AssetsDir. Contest materials (like statements of
+		// gyms, see Dashboard.Material) are saved in AssetsDir.
+		AssetsDir string
+	}
+
 	// Problem holds data of problem.
 	Problem struct {
 		Name        string
 		TimeLimit   string
 		MemoryLimit string
-		InpStream   string
-		OutStream   string
-		SampleTests []SampleTest
+		// Parsed values of TimeLimit and MemoryLimit (in bytes).
+		TimeLimitDuration time.Duration
+		MemoryLimitBytes  int64
+		InpStream         string
+		OutStream         string
+		SampleTests       []SampleTest
+		Statement         Statement
+		// Tags and difficulty rating (0 if unrated) of the problem.
+		Tags        []string
+		Rating      int
 		SolveCount  int
 		SolveStatus int
 		Arg         Args
@@ -37,9 +86,7 @@ const (
 	SolveAccepted
 )
 
-func (p *page) getProblems(arg Args) ([]Problem, error) {
-	pd := p.parse()
-
+func getProblems(pd *goquery.Document, arg Args) ([]Problem, error) {
 	problems := make([]Problem, 0)
 
 	problemsTable := pd.Find(`.problemindexholder`)
@@ -52,13 +99,8 @@ func (p *page) getProblems(arg Args) ([]Problem, error) {
 		for i, sampleInput, sampleOutput := 0, row.Find(`.input>pre`),
 			row.Find(`.output>pre`); i < sampleInput.Length(); i++ {
 
-			inpStr := p.MustEval(
-				fmt.Sprintf("document.querySelector(\"#%v\").innerText",
-					sampleInput.Eq(i).AttrOr(`id`, ``))).String()
-
-			outStr := p.MustEval(
-				fmt.Sprintf("document.querySelector(\"#%v\").innerText",
-					sampleOutput.Eq(i).AttrOr(`id`, ``))).String()
+			inpStr := innerText(sampleInput.Eq(i))
+			outStr := innerText(sampleOutput.Eq(i))
 
 			problem.SampleTests = append(problem.SampleTests, SampleTest{
 				Input: inpStr, Output: outStr,
@@ -70,15 +112,282 @@ func (p *page) getProblems(arg Args) ([]Problem, error) {
 		problem.Name = clean(header.Find(`.title`).Text())
 		problem.TimeLimit = clean(header.Find(`.time-limit`).Contents().Last().Text())
 		problem.MemoryLimit = clean(header.Find(`.memory-limit`).Contents().Last().Text())
+		problem.TimeLimitDuration = parseDuration(problem.TimeLimit)
+		problem.MemoryLimitBytes = parseMemory(problem.MemoryLimit)
 		problem.InpStream = clean(header.Find(".input-file").Contents().Last().Text())
 		problem.OutStream = clean(header.Find(".output-file").Contents().Last().Text())
 
+		problem.Statement = getStatement(row.Find(`.problem-statement`))
+
 		problems = append(problems, problem)
 	})
 
+	// Tags are shown only in the page of a single problem.
+	if len(problems) == 1 {
+		problems[0].Tags, problems[0].Rating = getProblemTags(pd)
+	}
+
 	return problems, nil
 }
 
+// getProblemTags returns the tags and difficulty rating of the
+// problem, from the sidebar of the problem page. Tags hidden (by
+// the settings of the user) till the problem is solved are parsed
+// too. Tags aren't shown at all while the contest is running.
+func getProblemTags(pd *goquery.Document) ([]string, int) {
+	var tags []string
+	rating := 0
+	pd.Find(`#sidebar .tag-box`).Each(func(_ int, box *goquery.Selection) {
+		tag := clean(box.Text())
+		// Rating is shown as a tag too ('*1700'),
+		// and so are special problems ('*special').
+		if val, err := strconv.Atoi(strings.TrimPrefix(tag, "*")); err == nil && tag[0] == '*' {
+			rating = val
+			return
+		}
+		tags = append(tags, tag)
+	})
+	return tags, rating
+}
+
+func getStatement(sel *goquery.Selection) Statement {
+	var statement Statement
+
+	// Section titles, if the class isn't a giveaway.
+	titles := map[string][]string{
+		"interaction": {"interaction", "протокол взаимодействия"},
+		"scoring":     {"scoring", "система оценки"},
+	}
+
+	sel.Children().Each(func(_ int, section *goquery.Selection) {
+		kind := ""
+		switch {
+		case section.HasClass("header"), section.HasClass("sample-tests"):
+			return
+		case section.HasClass("input-specification"):
+			kind = "input"
+		case section.HasClass("output-specification"):
+			kind = "output"
+		case section.HasClass("note"):
+			kind = "notes"
+		case section.HasClass("interaction"):
+			kind = "interaction"
+		case section.HasClass("scoring"):
+			kind = "scoring"
+		case section.Find(`.section-title`).Length() == 0:
+			kind = "legend"
+		default:
+			title := strings.ToLower(clean(section.Find(`.section-title`).Text()))
+			for k, v := range titles {
+				for _, str := range v {
+					if title == str {
+						kind = k
+					}
+				}
+			}
+		}
+
+		section = section.Clone()
+		section.Find(`.section-title`).Remove()
+		normalizeTeX(section)
+
+		html, _ := section.Html()
+		val := Section{
+			Text: clean(statementText(section)),
+			HTML: strings.TrimSpace(html),
+		}
+
+		switch kind {
+		case "legend":
+			statement.Legend = val
+		case "input":
+			statement.Input = val
+		case "output":
+			statement.Output = val
+		case "interaction":
+			statement.Interaction = val
+		case "scoring":
+			statement.Scoring = val
+		case "notes":
+			statement.Notes = val
+		}
+	})
+
+	return statement
+}
+
+// normalizeTeX replaces formulas, both raw ('$$$...$$$') and
+// rendered by MathJax, with '$...$' ('$$...$$' if displayed).
+func normalizeTeX(sel *goquery.Selection) {
+	// Drop the rendered formulas; the source is kept in scripts.
+	sel.Find(`.MathJax_Preview, .MathJax, .MathJax_Display, .MathJax_SVG, .MJX_Assistive_MathML`).Remove()
+
+	sel.Find(`script[type^="math/tex"]`).Each(func(_ int, script *goquery.Selection) {
+		delim := "$"
+		if strings.Contains(script.AttrOr("type", ""), "mode=display") {
+			delim = "$$"
+		}
+		script.ReplaceWithHtml(html.EscapeString(delim + strings.TrimSpace(script.Text()) + delim))
+	})
+
+	sel.Find(`*`).AddSelection(sel).Contents().Each(func(_ int, node *goquery.Selection) {
+		if goquery.NodeName(node) != "#text" {
+			return
+		}
+		text := node.Text()
+		if !strings.Contains(text, "$$$") {
+			return
+		}
+		text = strings.ReplaceAll(text, "$$$$$$", "$$")
+		text = strings.ReplaceAll(text, "$$$", "$")
+		node.ReplaceWithHtml(html.EscapeString(text))
+	})
+}
+
+// statementText returns the text of the given statement
+// section, with paragraphs (and lines) separated by '\n'.
+func statementText(sel *goquery.Selection) string {
+	var sb strings.Builder
+	sel.Contents().Each(func(_ int, node *goquery.Selection) {
+		switch goquery.NodeName(node) {
+		case "#text":
+			sb.WriteString(node.Text())
+		case "br":
+			sb.WriteString("\n")
+		case "p", "div", "li", "ul", "ol", "pre", "center", "table", "tr":
+			sb.WriteString("\n" + statementText(node) + "\n")
+		default:
+			sb.WriteString(statementText(node))
+		}
+	})
+	return sb.String()
+}
+
+// Extensions of links in statements, treated as attachments.
+var attachmentExts = map[string]bool{
+	".pdf": true, ".zip": true, ".txt": true, ".in": true, ".out": true,
+	".cpp": true, ".py": true, ".java": true, ".png": true, ".jpg": true,
+}
+
+// isAttachment reports if the link in a statement is a file
+// (and not a page) to be downloaded along with the statement.
+func isAttachment(link string) bool {
+	u, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+	return strings.Contains(u.Path, "/attachments/") ||
+		attachmentExts[strings.ToLower(path.Ext(u.Path))]
+}
+
+// assetName returns the base name of the file at the link path,
+// numbered if the name is already taken (present in names).
+func assetName(names map[string]bool, linkPath string) string {
+	name := path.Base(linkPath)
+	for j := 1; names[name]; j++ {
+		name = fmt.Sprintf("%v-%v", j, path.Base(linkPath))
+	}
+	names[name] = true
+	return name
+}
+
+// downloadAssets downloads all images and attachments referenced
+// by the statements of problems to dir, and rewrites the statement
+// html to point to the downloaded files (relative to dir). Relative
+// links are resolved against host. Each asset is downloaded once.
+func downloadAssets(ctx context.Context, client *http.Client, host string, problems []Problem, dir string) error {
+	base, err := url.Parse(host + "/")
+	if err != nil {
+		return err
+	}
+
+	for i := range problems {
+		// Absolute link => saved file path (relative to dir).
+		files := make(map[string]string)
+		names := make(map[string]bool)
+
+		statement := &problems[i].Statement
+		sections := []*Section{&statement.Legend, &statement.Input, &statement.Output,
+			&statement.Interaction, &statement.Scoring, &statement.Notes}
+
+		for _, section := range sections {
+			if section.HTML == "" {
+				continue
+			}
+
+			pd, err := goquery.NewDocumentFromReader(strings.NewReader(section.HTML))
+			if err != nil {
+				return err
+			}
+
+			pd.Find(`img[src], a[href]`).EachWithBreak(func(_ int, elm *goquery.Selection) bool {
+				attr := "src"
+				if goquery.NodeName(elm) == "a" {
+					attr = "href"
+					if !isAttachment(elm.AttrOr(attr, "")) {
+						return true
+					}
+				}
+
+				ref, e := url.Parse(elm.AttrOr(attr, ""))
+				if e != nil || ref.Scheme == "data" {
+					return true
+				}
+				link := base.ResolveReference(ref).String()
+
+				file, ok := files[link]
+				if !ok {
+					file = filepath.Join(problems[i].Arg.Problem, assetName(names, ref.Path))
+					if err = util.Download(ctx, client, link, filepath.Join(dir, file)); err != nil {
+						return false
+					}
+					files[link] = file
+				}
+
+				// Links in html are separated by '/' on all platforms.
+				elm.SetAttr(attr, filepath.ToSlash(file))
+				return true
+			})
+			if err != nil {
+				return err
+			}
+
+			html, _ := pd.Find(`body`).Html()
+			section.HTML = strings.TrimSpace(html)
+		}
+	}
+
+	return nil
+}
+
+// downloadMaterial downloads all files listed in the contest
+// materials (in the sidebar of the page) to dir. Links to pages
+// (like announcements) are skipped.
+func downloadMaterial(ctx context.Context, client *http.Client, host string, pd *goquery.Document, dir string) error {
+	base, err := url.Parse(host + "/")
+	if err != nil {
+		return err
+	}
+
+	names := make(map[string]bool)
+	pd.Find(`#sidebar li a[href]`).EachWithBreak(func(_ int, elm *goquery.Selection) bool {
+		href := elm.AttrOr("href", "")
+		if !isAttachment(href) {
+			return true
+		}
+
+		ref, e := url.Parse(href)
+		if e != nil {
+			return true
+		}
+
+		link := base.ResolveReference(ref).String()
+		err = util.Download(ctx, client, link, filepath.Join(dir, assetName(names, ref.Path)))
+		return err == nil
+	})
+	return err
+}
+
 // GetProblems returns problem(s) meta data, along with sample tests.
 //
 // If the problem is not specified, returns data of all problems in
@@ -87,34 +396,96 @@ func (p *page) getProblems(arg Args) ([]Problem, error) {
 // GetProblems() for each problem in the contest.
 //
 // SolveStatus and SolveCount are not parsed by this.
-// Use GetDashboard() if you require these fields.
+// Use GetDashboard() if you require these fields. Tags and
+// Rating are parsed only if the problem is specified.
 func (arg Args) GetProblems() ([]Problem, error) {
-	link, err := arg.ProblemsPage()
-	if err != nil {
-		return nil, err
-	}
+	return DefaultClient.GetProblems(arg)
+}
 
-	p, err := loadPage(link)
+// GetProblemsContext is the same as GetProblems, using the given context.
+func (arg Args) GetProblemsContext(ctx context.Context) ([]Problem, error) {
+	return DefaultClient.GetProblemsContext(ctx, arg)
+}
+
+// GetProblems returns problem(s) meta data, along with sample tests.
+// See Args.GetProblems for more details.
+func (c *Client) GetProblems(arg Args) ([]Problem, error) {
+	return c.GetProblemsContext(context.Background(), arg)
+}
+
+// GetProblemsContext is the same as GetProblems, using the given context.
+func (c *Client) GetProblemsContext(ctx context.Context, arg Args) ([]Problem, error) {
+	return c.GetProblemsWithOptionsContext(ctx, arg, ProblemsOptions{})
+}
+
+// GetProblemsWithOptions is the same as GetProblems, with
+// the given options. See ProblemsOptions for the options.
+func (arg Args) GetProblemsWithOptions(opts ProblemsOptions) ([]Problem, error) {
+	return DefaultClient.GetProblemsWithOptions(arg, opts)
+}
+
+// GetProblemsWithOptionsContext is the same as GetProblemsWithOptions, using the given context.
+func (arg Args) GetProblemsWithOptionsContext(ctx context.Context, opts ProblemsOptions) ([]Problem, error) {
+	return DefaultClient.GetProblemsWithOptionsContext(ctx, arg, opts)
+}
+
+// GetProblemsWithOptions is the same as GetProblems, with
+// the given options. See ProblemsOptions for the options.
+func (c *Client) GetProblemsWithOptions(arg Args, opts ProblemsOptions) ([]Problem, error) {
+	return c.GetProblemsWithOptionsContext(context.Background(), arg, opts)
+}
+
+// GetProblemsWithOptionsContext is the same as GetProblemsWithOptions, using the given context.
+func (c *Client) GetProblemsWithOptionsContext(ctx context.Context, arg Args, opts ProblemsOptions) ([]Problem, error) {
+	link, err := c.ProblemsPage(arg)
 	if err != nil {
 		return nil, err
 	}
-	defer p.Close()
 
-	if _, err := p.Race().Element(`#jGrowl .message`).Handle(handleErrMsg).
-		Element(`.problemindexholder`).Do(); err != nil {
-		return nil, err
-	}
+	var (
+		pd     *goquery.Document
+		client *http.Client
+	)
+	if c != nil && c.web != nil {
+		if pd, err = c.web.get(ctx, link); err != nil {
+			return nil, err
+		}
+		client = c.web.client
+	} else {
+		p, err := c.loadPage(ctx, link)
+		if err != nil {
+			return nil, err
+		}
+		defer p.Close()
+
+		if err := p.waitFor(link, `.problemindexholder`); err != nil {
+			return nil, err
+		}
+
+		// Wait till all problems have loaded.
+		p.WaitLoad()
 
-	if p.MustInfo().URL != link {
-		// An unexpected redirect occurred.
-		// Return error notification.
-		return nil, handleErrMsg(p.MustElement(`#jGrowl .message`))
+		// Parse samples as rendered by the browser.
+		if err := p.renderText(`.input>pre, .output>pre`); err != nil {
+			return nil, err
+		}
+
+		pd, client = p.parse(), p.httpClient()
 	}
 
-	// Wait till all problems have loaded.
-	p.WaitLoad()
+	problems, err := getProblems(pd, arg)
+	if err != nil || opts.AssetsDir == "" {
+		return problems, err
+	}
 
-	return p.getProblems(arg)
+	// Images aren't loaded by the page; download them separately.
+	if err := downloadAssets(ctx, client, c.Host(), problems, opts.AssetsDir); err != nil {
+		return nil, err
+	}
+	if err := downloadMaterial(ctx, client, c.Host(), pd, opts.AssetsDir); err != nil {
+		return nil, err
+	}
+	return problems, nil
 }
 
 // SubmitSolution submits given file to the judging server,
@@ -122,16 +493,38 @@ func (arg Args) GetProblems() ([]Problem, error) {
 // The channel contains the live status of the submission.
 // View GetSubmissions() for more details on the returned channel.
 //
-// langName is the codeforces configured language to use. See the
-// variable map LanguageID for the list of supported languages.
+// langName is the codeforces configured language to use, by its
+// full name. See GetLanguages for the list of languages allowed
+// in the problem. ErrLanguageNotAllowed is returned if a language
+// in LanguageID isn't allowed; ErrInvalidLanguage is returned
+// for any other unlisted language.
 func (arg Args) SubmitSolution(langName string, file string) (<-chan Submission, error) {
+	return DefaultClient.SubmitSolution(arg, langName, file)
+}
+
+// SubmitSolutionContext is the same as SubmitSolution, using the given context.
+func (arg Args) SubmitSolutionContext(ctx context.Context, langName string, file string) (<-chan Submission, error) {
+	return DefaultClient.SubmitSolutionContext(ctx, arg, langName, file)
+}
+
+// SubmitSolution submits given file to the judging server.
+// See Args.SubmitSolution for more details.
+func (c *Client) SubmitSolution(arg Args, langName string, file string) (<-chan Submission, error) {
+	return c.SubmitSolutionContext(context.Background(), arg, langName, file)
+}
+
+// SubmitSolutionContext is the same as SubmitSolution, using the given context.
+//
+// Once ctx is done, polling of the verdict is stopped, the
+// browser tab is closed and the returned channel is closed.
+func (c *Client) SubmitSolutionContext(ctx context.Context, arg Args, langName string, file string) (<-chan Submission, error) {
 	// problem not specified, return invalid
 	if arg.Problem == "" {
 		return nil, ErrInvalidSpecifier
 	}
 
-	if _, ok := LanguageID[langName]; !ok {
-		return nil, fmt.Errorf("invalid language")
+	if strings.TrimSpace(langName) == "" {
+		return nil, ErrInvalidLanguage
 	}
 
 	// check if given file exists
@@ -139,56 +532,41 @@ func (arg Args) SubmitSolution(langName string, file string) (<-chan Submission,
 		return nil, fmt.Errorf("invalid file path")
 	}
 
-	link, err := arg.ProblemsPage()
+	link, err := c.ProblemsPage(arg)
 	if err != nil {
 		return nil, err
 	}
 
-	p, err := loadPage(link)
+	p, err := c.loadPage(ctx, link)
 	if err != nil {
 		return nil, err
 	}
 
-	if _, err := p.Race().Element(`#jGrowl .message`).Handle(handleErrMsg).
-		Element(`#footer`).Do(); err != nil {
+	if err := p.waitFor(link, `#footer`); err != nil {
 		p.Close()
 		return nil, err
 	}
 
-	if p.MustInfo().URL != link {
-		p.Close()
-		// An unexpected redirect occurred.
-		// Return error notification.
-		return nil, handleErrMsg(p.MustElement(`#jGrowl .message`))
-	}
-
-	// Check if user is logged in.
-	if !p.MustHas(`#header a[href^="/profile/"]`) {
-		p.Close()
-		return nil, fmt.Errorf("no logged in session present")
-	}
-
-	// Check if submitting is possible at all.
-	if !p.MustHas(`input.submit`) {
+	langID, err := checkSubmit(p.parse(), langName)
+	if err != nil {
 		p.Close()
-		return nil, fmt.Errorf("problem not open for submission")
+		return nil, err
 	}
 
-	// Check if specified language can be selected.
-	// If this is allowed, so is submitting.
-	if !p.MustHasR(`select>option[value]`, regexp.QuoteMeta(langName)) {
+	// All cases have been handled. Submit the solution.
+	if err := rod.Try(func() {
+		// Select the language by id; names may be prefixes of others.
+		mustSelectValue(p.MustElement(`select[name="programTypeId"]`), langID)
+		p.MustElement(`input[name="sourceFile"]`).MustSetFiles(file)
+		p.MustElement(`input.submit`).MustClick().WaitInvisible()
+	}); err != nil {
 		p.Close()
-		return nil, fmt.Errorf("language not allowed in problem")
+		return nil, err
 	}
 
-	// All cases have been handled. Submit the solution.
-	p.MustElement(`select[name="programTypeId"]`).MustSelect(langName)
-	p.MustElement(`input[name="sourceFile"]`).MustSetFiles(file)
-	p.MustElement(`input.submit`).MustClick().WaitInvisible()
-
 	if _, err := p.Race().Element(`.error`).Handle(handleErrMsg).
 		Element(`tr[data-submission-id]`).Do(); err != nil {
-		// Example error message: "exact submission done before"
+		// Example error message: "You have submitted exactly the same code before"
 		p.Close()
 		return nil, err
 	}
@@ -200,22 +578,100 @@ func (arg Args) SubmitSolution(langName string, file string) (<-chan Submission,
 		defer close(chanSubmission)
 
 		for {
-			submissions, _ := p.getSubmissions(arg)
+			submissions, _ := getSubmissions(p.parse(), arg)
 			if len(submissions) == 0 {
 				break
 			}
 
-			chanSubmission <- submissions[0]
+			select {
+			case chanSubmission <- submissions[0]:
+			case <-ctx.Done():
+				return
+			}
+
 			if !submissions[0].IsJudging {
 				break
 			}
 
 			// Wait for atleast 1.5 seconds before parsing again.
 			timer := time.Now()
-			p.MustReload().WaitLoad()
-			time.Sleep(time.Millisecond*1500 - time.Since(timer))
+			if err := p.Reload(); err != nil {
+				break
+			}
+			p.WaitLoad()
+			if !p.sleep(time.Millisecond*1500 - time.Since(timer)) {
+				break
+			}
 		}
 	}()
 
 	return chanSubmission, nil
 }
+
+// SubmitSource submits given source code to the judging server.
+// It is the same as SubmitSolution, except that the source code
+// is taken from memory (and not a file).
+func (arg Args) SubmitSource(langName string, source string) (<-chan Submission, error) {
+	return DefaultClient.SubmitSource(arg, langName, source)
+}
+
+// SubmitSourceContext is the same as SubmitSource, using the given context.
+func (arg Args) SubmitSourceContext(ctx context.Context, langName string, source string) (<-chan Submission, error) {
+	return DefaultClient.SubmitSourceContext(ctx, arg, langName, source)
+}
+
+// SubmitSource submits given source code to the judging server.
+// See Args.SubmitSource for more details.
+func (c *Client) SubmitSource(arg Args, langName string, source string) (<-chan Submission, error) {
+	return c.SubmitSourceContext(context.Background(), arg, langName, source)
+}
+
+// SubmitSourceContext is the same as SubmitSource, using the given context.
+func (c *Client) SubmitSourceContext(ctx context.Context, arg Args, langName string, source string) (<-chan Submission, error) {
+	if strings.TrimSpace(source) == "" {
+		return nil, fmt.Errorf("empty source code")
+	}
+
+	// The source is uploaded (as is done for files)
+	// from a temporary file, removed once submitted.
+	file, err := ioutil.TempFile("", "cpt-source-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.WriteString(source); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if err := file.Close(); err != nil {
+		return nil, err
+	}
+
+	return c.SubmitSolutionContext(ctx, arg, langName, file.Name())
+}
+
+// checkSubmit returns the id of the given language, as listed in
+// the submit form in the page, or an error if a solution in the
+// language can't be submitted. Languages are matched by name.
+func checkSubmit(pd *goquery.Document, langName string) (string, error) {
+	languages, err := getLanguages(pd)
+	if err != nil {
+		return "", err
+	}
+	if pd.Find(`input.submit`).Length() == 0 {
+		return "", ErrSubmissionClosed
+	}
+
+	langName = clean(langName)
+	for _, lang := range languages {
+		if lang.Name == langName {
+			return lang.ID, nil
+		}
+	}
+
+	if _, ok := LanguageID[langName]; ok {
+		return "", ErrLanguageNotAllowed
+	}
+	return "", ErrInvalidLanguage
+}
@@ -11,6 +11,7 @@ import (
 )
 
 func TestArgs_GetProblems(t *testing.T) {
+	skipOffline(t)
 	//time.Sleep(time.Second * 10)
 
 	tests := []struct {
@@ -24,11 +25,13 @@ func TestArgs_GetProblems(t *testing.T) {
 			arg:  Args{"4", "", "contest", ""},
 			want: []Problem{
 				{
-					Name:        "A. Watermelon",
-					TimeLimit:   "1 second",
-					MemoryLimit: "64 megabytes",
-					InpStream:   "standard input",
-					OutStream:   "standard output",
+					Name:              "A. Watermelon",
+					TimeLimit:         "1 second",
+					MemoryLimit:       "64 megabytes",
+					TimeLimitDuration: time.Second,
+					MemoryLimitBytes:  64 << 20,
+					InpStream:         "standard input",
+					OutStream:         "standard output",
 					SampleTests: []SampleTest{
 						{
 							Input:  "8\n",
@@ -38,11 +41,13 @@ func TestArgs_GetProblems(t *testing.T) {
 					Arg: Args{"4", "a", "contest", ""},
 				},
 				{
-					Name:        "B. Before an Exam",
-					TimeLimit:   "0.5 second",
-					MemoryLimit: "64 megabytes",
-					InpStream:   "standard input",
-					OutStream:   "standard output",
+					Name:              "B. Before an Exam",
+					TimeLimit:         "0.5 second",
+					MemoryLimit:       "64 megabytes",
+					TimeLimitDuration: time.Millisecond * 500,
+					MemoryLimitBytes:  64 << 20,
+					InpStream:         "standard input",
+					OutStream:         "standard output",
 					SampleTests: []SampleTest{
 						{
 							Input:  "1 48\n5 7\n",
@@ -56,11 +61,13 @@ func TestArgs_GetProblems(t *testing.T) {
 					Arg: Args{"4", "b", "contest", ""},
 				},
 				{
-					Name:        "C. Registration system",
-					TimeLimit:   "5 seconds",
-					MemoryLimit: "64 megabytes",
-					InpStream:   "standard input",
-					OutStream:   "standard output",
+					Name:              "C. Registration system",
+					TimeLimit:         "5 seconds",
+					MemoryLimit:       "64 megabytes",
+					TimeLimitDuration: time.Second * 5,
+					MemoryLimitBytes:  64 << 20,
+					InpStream:         "standard input",
+					OutStream:         "standard output",
 					SampleTests: []SampleTest{
 						{
 							Input:  "4\nabacaba\nacaba\nabacaba\nacab\n",
@@ -74,11 +81,13 @@ func TestArgs_GetProblems(t *testing.T) {
 					Arg: Args{"4", "c", "contest", ""},
 				},
 				{
-					Name:        "D. Mysterious Present",
-					TimeLimit:   "1 second",
-					MemoryLimit: "64 megabytes",
-					InpStream:   "standard input",
-					OutStream:   "standard output",
+					Name:              "D. Mysterious Present",
+					TimeLimit:         "1 second",
+					MemoryLimit:       "64 megabytes",
+					TimeLimitDuration: time.Second,
+					MemoryLimitBytes:  64 << 20,
+					InpStream:         "standard input",
+					OutStream:         "standard output",
 					SampleTests: []SampleTest{
 						{
 							Input:  "2 1 1\n2 2\n2 2\n",
@@ -99,11 +108,13 @@ func TestArgs_GetProblems(t *testing.T) {
 			arg:  Args{"1234", "a", "contest", ""},
 			want: []Problem{
 				{
-					Name:        "A. Equalize Prices Again",
-					TimeLimit:   "1 second",
-					MemoryLimit: "256 megabytes",
-					InpStream:   "standard input",
-					OutStream:   "standard output",
+					Name:              "A. Equalize Prices Again",
+					TimeLimit:         "1 second",
+					MemoryLimit:       "256 megabytes",
+					TimeLimitDuration: time.Second,
+					MemoryLimitBytes:  256 << 20,
+					InpStream:         "standard input",
+					OutStream:         "standard output",
 					SampleTests: []SampleTest{
 						{
 							Input:  "3\n5\n1 2 3 4 5\n3\n1 2 2\n4\n1 1 1 1\n",
@@ -120,11 +131,13 @@ func TestArgs_GetProblems(t *testing.T) {
 			arg:  Args{"101189", "", "gym", ""},
 			want: []Problem{
 				{
-					Name:        "A. Arpa’s hard exam and Mehrdad’s naive cheat(Hard)",
-					TimeLimit:   "1 second",
-					MemoryLimit: "256 megabytes",
-					InpStream:   "standard input",
-					OutStream:   "standard output",
+					Name:              "A. Arpa’s hard exam and Mehrdad’s naive cheat(Hard)",
+					TimeLimit:         "1 second",
+					MemoryLimit:       "256 megabytes",
+					TimeLimitDuration: time.Second,
+					MemoryLimitBytes:  256 << 20,
+					InpStream:         "standard input",
+					OutStream:         "standard output",
 					SampleTests: []SampleTest{
 						{
 							Input:  "1\n",
@@ -138,11 +151,13 @@ func TestArgs_GetProblems(t *testing.T) {
 					Arg: Args{"101189", "a", "gym", ""},
 				},
 				{
-					Name:        "B. Arpa’s obvious problem and Mehrdad’s terrible solution(Hard)",
-					TimeLimit:   "0.5 seconds",
-					MemoryLimit: "512 megabytes",
-					InpStream:   "standard input",
-					OutStream:   "standard output",
+					Name:              "B. Arpa’s obvious problem and Mehrdad’s terrible solution(Hard)",
+					TimeLimit:         "0.5 seconds",
+					MemoryLimit:       "512 megabytes",
+					TimeLimitDuration: time.Millisecond * 500,
+					MemoryLimitBytes:  512 << 20,
+					InpStream:         "standard input",
+					OutStream:         "standard output",
 					SampleTests: []SampleTest{
 						{
 							Input:  "2 3\n1 2\n",
@@ -156,11 +171,13 @@ func TestArgs_GetProblems(t *testing.T) {
 					Arg: Args{"101189", "b", "gym", ""},
 				},
 				{
-					Name:        "C. Arpa's loud Owf and Mehrdad's evil plan(Hard)",
-					TimeLimit:   "3 seconds",
-					MemoryLimit: "256 megabytes",
-					InpStream:   "standard input",
-					OutStream:   "standard output",
+					Name:              "C. Arpa's loud Owf and Mehrdad's evil plan(Hard)",
+					TimeLimit:         "3 seconds",
+					MemoryLimit:       "256 megabytes",
+					TimeLimitDuration: time.Second * 3,
+					MemoryLimitBytes:  256 << 20,
+					InpStream:         "standard input",
+					OutStream:         "standard output",
 					SampleTests: []SampleTest{
 						{
 							Input:  "4\n2 3 1 4\n",
@@ -178,11 +195,13 @@ func TestArgs_GetProblems(t *testing.T) {
 					Arg: Args{"101189", "c", "gym", ""},
 				},
 				{
-					Name:        "D. Arpa’s letter-marked tree and Mehrdad’s Dokhtar-kosh paths(Hard)",
-					TimeLimit:   "2 seconds",
-					MemoryLimit: "256 megabytes",
-					InpStream:   "standard input",
-					OutStream:   "standard output",
+					Name:              "D. Arpa’s letter-marked tree and Mehrdad’s Dokhtar-kosh paths(Hard)",
+					TimeLimit:         "2 seconds",
+					MemoryLimit:       "256 megabytes",
+					TimeLimitDuration: time.Second * 2,
+					MemoryLimitBytes:  256 << 20,
+					InpStream:         "standard input",
+					OutStream:         "standard output",
 					SampleTests: []SampleTest{
 						{
 							Input:  "4\n1 s\n2 a\n3 s\n",
@@ -203,11 +222,13 @@ func TestArgs_GetProblems(t *testing.T) {
 			arg:  Args{"102391", "g", "gym", ""},
 			want: []Problem{
 				{
-					Name:        "G. Lexicographically Minimum Walk",
-					TimeLimit:   "2 seconds",
-					MemoryLimit: "1024 megabytes",
-					InpStream:   "standard input",
-					OutStream:   "standard output",
+					Name:              "G. Lexicographically Minimum Walk",
+					TimeLimit:         "2 seconds",
+					MemoryLimit:       "1024 megabytes",
+					TimeLimitDuration: time.Second * 2,
+					MemoryLimitBytes:  1024 << 20,
+					InpStream:         "standard input",
+					OutStream:         "standard output",
 					SampleTests: []SampleTest{
 						{
 							Input:  "3 3 1 3\n1 2 1\n2 3 7\n1 3 5\n",
@@ -232,11 +253,13 @@ func TestArgs_GetProblems(t *testing.T) {
 			arg:  Args{"283855", "", "group", "bK73bvp3d7"},
 			want: []Problem{
 				{
-					Name:        "A. Buggy Robot",
-					TimeLimit:   "2 seconds",
-					MemoryLimit: "256 megabytes",
-					InpStream:   "standard input",
-					OutStream:   "standard output",
+					Name:              "A. Buggy Robot",
+					TimeLimit:         "2 seconds",
+					MemoryLimit:       "256 megabytes",
+					TimeLimitDuration: time.Second * 2,
+					MemoryLimitBytes:  256 << 20,
+					InpStream:         "standard input",
+					OutStream:         "standard output",
 					SampleTests: []SampleTest{
 						{
 							Input:  "4\nLDUR\n",
@@ -254,11 +277,13 @@ func TestArgs_GetProblems(t *testing.T) {
 					Arg: Args{"283855", "a", "group", "bK73bvp3d7"},
 				},
 				{
-					Name:        "B. Two Cakes",
-					TimeLimit:   "1 second",
-					MemoryLimit: "256 megabytes",
-					InpStream:   "standard input",
-					OutStream:   "standard output",
+					Name:              "B. Two Cakes",
+					TimeLimit:         "1 second",
+					MemoryLimit:       "256 megabytes",
+					TimeLimitDuration: time.Second,
+					MemoryLimitBytes:  256 << 20,
+					InpStream:         "standard input",
+					OutStream:         "standard output",
 					SampleTests: []SampleTest{
 						{
 							Input:  "5 2 3\n",
@@ -272,11 +297,13 @@ func TestArgs_GetProblems(t *testing.T) {
 					Arg: Args{"283855", "b", "group", "bK73bvp3d7"},
 				},
 				{
-					Name:        "C. Odd sum",
-					TimeLimit:   "1 second",
-					MemoryLimit: "256 megabytes",
-					InpStream:   "standard input",
-					OutStream:   "standard output",
+					Name:              "C. Odd sum",
+					TimeLimit:         "1 second",
+					MemoryLimit:       "256 megabytes",
+					TimeLimitDuration: time.Second,
+					MemoryLimitBytes:  256 << 20,
+					InpStream:         "standard input",
+					OutStream:         "standard output",
 					SampleTests: []SampleTest{
 						{
 							Input:  "4\n-2 2 -3 1\n",
@@ -290,11 +317,13 @@ func TestArgs_GetProblems(t *testing.T) {
 					Arg: Args{"283855", "c", "group", "bK73bvp3d7"},
 				},
 				{
-					Name:        "D. Number of Ways",
-					TimeLimit:   "2 seconds",
-					MemoryLimit: "256 megabytes",
-					InpStream:   "standard input",
-					OutStream:   "standard output",
+					Name:              "D. Number of Ways",
+					TimeLimit:         "2 seconds",
+					MemoryLimit:       "256 megabytes",
+					TimeLimitDuration: time.Second * 2,
+					MemoryLimitBytes:  256 << 20,
+					InpStream:         "standard input",
+					OutStream:         "standard output",
 					SampleTests: []SampleTest{
 						{
 							Input:  "5\n1 2 3 0 3\n",
@@ -312,11 +341,13 @@ func TestArgs_GetProblems(t *testing.T) {
 					Arg: Args{"283855", "d", "group", "bK73bvp3d7"},
 				},
 				{
-					Name:        "E. Propagating tree",
-					TimeLimit:   "2 seconds",
-					MemoryLimit: "256 megabytes",
-					InpStream:   "standard input",
-					OutStream:   "standard output",
+					Name:              "E. Propagating tree",
+					TimeLimit:         "2 seconds",
+					MemoryLimit:       "256 megabytes",
+					TimeLimitDuration: time.Second * 2,
+					MemoryLimitBytes:  256 << 20,
+					InpStream:         "standard input",
+					OutStream:         "standard output",
 					SampleTests: []SampleTest{
 						{
 							Input:  "5 5\n1 2 1 1 2\n1 2\n1 3\n2 4\n2 5\n1 2 3\n1 1 2\n2 1\n2 2\n2 4\n",
@@ -339,11 +370,13 @@ func TestArgs_GetProblems(t *testing.T) {
 			arg:  Args{"277493", "t", "group", "MEqF8b6wBT"},
 			want: []Problem{
 				{
-					Name:        "T. Rhombuses Inside Rectangle",
-					TimeLimit:   "2 seconds",
-					MemoryLimit: "256 megabytes",
-					InpStream:   "rect.in",
-					OutStream:   "standard output",
+					Name:              "T. Rhombuses Inside Rectangle",
+					TimeLimit:         "2 seconds",
+					MemoryLimit:       "256 megabytes",
+					TimeLimitDuration: time.Second * 2,
+					MemoryLimitBytes:  256 << 20,
+					InpStream:         "rect.in",
+					OutStream:         "standard output",
 					SampleTests: []SampleTest{
 						{
 							Input:  "3\n1 1\n2 2\n2 3\n",
@@ -365,6 +398,16 @@ func TestArgs_GetProblems(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := tt.arg.GetProblems()
+			for i := range got {
+				// Statements are checked in full in offline tests.
+				if st := got[i].Statement; st.Legend.Text == "" || st.Input.Text == "" || st.Output.Text == "" {
+					t.Errorf("Args.GetProblems() statement = %v, want legend, input and output", st)
+				}
+				got[i].Statement = Statement{}
+				// Tags keep changing; checked in offline tests.
+				got[i].Tags, got[i].Rating = nil, 0
+			}
+
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Args.GetProblems() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -377,6 +420,7 @@ func TestArgs_GetProblems(t *testing.T) {
 }
 
 func TestArgs_SubmitSolution(t *testing.T) {
+	skipOffline(t)
 	time.Sleep(time.Second * 10)
 
 	sFile, _ := ioutil.TempFile(os.TempDir(), "cpt-submission")
@@ -464,6 +508,29 @@ func TestArgs_SubmitSolution(t *testing.T) {
 	}
 }
 
+func TestArgs_SubmitSource(t *testing.T) {
+	skipOffline(t)
+	time.Sleep(time.Second * 10)
+
+	arg := Args{"5", "a", "contest", ""}
+	if _, err := arg.SubmitSource("GNU G++17 7.3.0", "  "); err == nil {
+		t.Errorf("Args.SubmitSource() expected error for empty source")
+	}
+
+	submission, err := arg.SubmitSource("GNU G++17 7.3.0", genRandomString(30))
+	if err != nil {
+		t.Fatalf("Args.SubmitSource() error = %v", err)
+	}
+
+	finalSub := Submission{}
+	for sub := range submission {
+		finalSub = sub
+	}
+	if finalSub.Verdict != "Compilation error" {
+		t.Errorf("Args.SubmitSource() finalSub = %v", finalSub)
+	}
+}
+
 // genRandomString generates a random string of length n.
 // Code copied from https://stackoverflow.com/a/9606036.
 func genRandomString(n int) string {
@@ -0,0 +1,190 @@
+package codeforces
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/go-rod/rod"
+)
+
+// ProblemsetFilter holds filters of problems in the problemset.
+type ProblemsetFilter struct {
+	// Tags lists the tags problems must all have.
+	Tags []string
+	// MinRating and MaxRating bound the difficulty rating of
+	// problems. Unrated problems are omitted if either is set.
+	// A zero value leaves the corresponding end unbounded.
+	MinRating int
+	MaxRating int
+	// HideSolved omits problems solved by the logged in user.
+	HideSolved bool
+}
+
+// maxProblemRating is the highest difficulty rating of problems.
+const maxProblemRating = 3500
+
+// match reports whether problem passes the filter.
+func (filter ProblemsetFilter) match(problem Problem) bool {
+	if filter.HideSolved && problem.SolveStatus == SolveAccepted {
+		return false
+	}
+
+	if filter.MinRating != 0 || filter.MaxRating != 0 {
+		if problem.Rating == 0 || problem.Rating < filter.MinRating ||
+			(filter.MaxRating != 0 && problem.Rating > filter.MaxRating) {
+			return false
+		}
+	}
+
+	for _, tag := range filter.Tags {
+		found := false
+		for _, val := range problem.Tags {
+			found = found || strings.EqualFold(val, tag)
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// filter returns the problems passing the filter.
+func (filter ProblemsetFilter) filter(problems []Problem) []Problem {
+	filtered := make([]Problem, 0, len(problems))
+	for _, problem := range problems {
+		if filter.match(problem) {
+			filtered = append(filtered, problem)
+		}
+	}
+	return filtered
+}
+
+func getProblemset(pd *goquery.Document) ([]Problem, error) {
+	problems := make([]Problem, 0)
+
+	problemsTable := pd.Find(`table.problems tr`).Has(`td`)
+	problemsTable.Each(func(_ int, row *goquery.Selection) {
+		var problem Problem
+
+		problem.Arg, _ = Parse(hostURL + row.Find(`td.id a`).AttrOr(`href`, ``))
+
+		row.Find(`td`).Each(func(cellIndex int, cell *goquery.Selection) {
+			switch cellIndex {
+			case 1:
+				// Name is followed by the tags of the problem.
+				problem.Name = clean(cell.Find(`div`).First().Find(`a`).Text())
+				cell.Find(`a.notice`).Each(func(_ int, tag *goquery.Selection) {
+					problem.Tags = append(problem.Tags, clean(tag.Text()))
+				})
+
+			case 3:
+				problem.Rating, _ = strconv.Atoi(clean(cell.Find(`.ProblemRating`).Text()))
+
+			case 4:
+				if solveStr := clean(cell.Text()); len(solveStr) > 1 {
+					// Remove the 'x' prefix from the string.
+					problem.SolveCount, _ = strconv.Atoi(solveStr[1:])
+				}
+			}
+		})
+
+		// Extract solve status.
+		switch row.AttrOr(`class`, ``) {
+		case "accepted-problem":
+			problem.SolveStatus = SolveAccepted
+		case "rejected-problem":
+			problem.SolveStatus = SolveRejected
+		default:
+			problem.SolveStatus = SolveNotAttempted
+		}
+
+		problems = append(problems, problem)
+	})
+	return problems, nil
+}
+
+// GetProblemset returns problems in the problemset (latest first),
+// matching the given filter. Only the name, tags, difficulty rating,
+// solve count and solve status of each problem are parsed.
+//
+// Set 'pageCount' to the maximum number of pages to parse.
+// Each page consists of (at most) 100 problems. Solved problems
+// are hidden once parsed, and thus pages may hold fewer problems
+// if HideSolved is set. If the source is SourceAPI, problems are
+// fetched through the API (and solve status is not parsed), unless
+// HideSolved is set. Uses DefaultClient.
+func GetProblemset(filter ProblemsetFilter, pageCount uint) (<-chan []Problem, error) {
+	return DefaultClient.GetProblemset(filter, pageCount)
+}
+
+// GetProblemsetContext is the same as GetProblemset, using the given context.
+func GetProblemsetContext(ctx context.Context, filter ProblemsetFilter, pageCount uint) (<-chan []Problem, error) {
+	return DefaultClient.GetProblemsetContext(ctx, filter, pageCount)
+}
+
+// GetProblemset returns problems in the problemset.
+// See GetProblemset for more details.
+func (c *Client) GetProblemset(filter ProblemsetFilter, pageCount uint) (<-chan []Problem, error) {
+	return c.GetProblemsetContext(context.Background(), filter, pageCount)
+}
+
+// GetProblemsetContext is the same as GetProblemset, using the given context.
+//
+// Once ctx is done, parsing is stopped, the browser tab
+// is closed and the returned channel is closed.
+func (c *Client) GetProblemsetContext(ctx context.Context, filter ProblemsetFilter, pageCount uint) (<-chan []Problem, error) {
+	link, err := c.problemsetPage(filter, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	// Solve status isn't returned by the API.
+	if c != nil && c.api != nil && !filter.HideSolved {
+		return c.api.pageProblemset(ctx, filter, pageCount)
+	}
+	if c != nil && c.web != nil {
+		return c.web.pageProblemset(ctx, link, pageCount, filter)
+	}
+
+	p, err := c.loadPage(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.waitFor(link, `table.problems`); err != nil {
+		p.Close()
+		return nil, err
+	}
+
+	chanProblems := make(chan []Problem)
+	go func() {
+		defer p.Close()
+		defer close(chanProblems)
+
+		// Must methods panic once the context is done.
+		rod.Try(func() {
+			for page := 1; uint(page) <= pageCount; page++ {
+				// Ignore error, write whatever is parsed.
+				problems, _ := getProblemset(p.parse())
+				select {
+				case chanProblems <- filter.filter(problems):
+				case <-ctx.Done():
+					return
+				}
+
+				if !p.MustHasR(`.pagination li>a`, `→`) {
+					// All pages parsed.
+					break
+				}
+
+				link, _ := c.problemsetPage(filter, page+1)
+				p.MustNavigate(link).MustElement(`table.problems`)
+				p.WaitLoad()
+			}
+		})
+	}()
+
+	return chanProblems, nil
+}
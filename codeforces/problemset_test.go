@@ -0,0 +1,90 @@
+package codeforces
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestProblemsetFilter_filter(t *testing.T) {
+	problems := []Problem{
+		{Name: "Watermelon", Tags: []string{"brute force", "math"}, Rating: 800, SolveStatus: SolveAccepted},
+		{Name: "Mysterious Present", Tags: []string{"dp", "sortings"}, Rating: 1700, SolveStatus: SolveRejected},
+		{Name: "Cheat and Win", SolveStatus: SolveNotAttempted},
+	}
+
+	tests := []struct {
+		name   string
+		filter ProblemsetFilter
+		want   []string
+	}{
+		{
+			name:   "Test #1",
+			filter: ProblemsetFilter{},
+			want:   []string{"Watermelon", "Mysterious Present", "Cheat and Win"},
+		},
+		{
+			name:   "Test #2",
+			filter: ProblemsetFilter{HideSolved: true},
+			want:   []string{"Mysterious Present", "Cheat and Win"},
+		},
+		{
+			name:   "Test #3", // Unrated problems are omitted.
+			filter: ProblemsetFilter{MaxRating: 1000},
+			want:   []string{"Watermelon"},
+		},
+		{
+			name:   "Test #4",
+			filter: ProblemsetFilter{MinRating: 1000},
+			want:   []string{"Mysterious Present"},
+		},
+		{
+			name:   "Test #5",
+			filter: ProblemsetFilter{Tags: []string{"DP", "sortings"}},
+			want:   []string{"Mysterious Present"},
+		},
+		{
+			name:   "Test #6",
+			filter: ProblemsetFilter{Tags: []string{"dp", "math"}},
+			want:   []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, problem := range tt.filter.filter(problems) {
+				got = append(got, problem.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ProblemsetFilter.filter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetProblemset(t *testing.T) {
+	skipOffline(t)
+	time.Sleep(time.Second * 10)
+
+	filter := ProblemsetFilter{Tags: []string{"dp"}, MinRating: 1500, MaxRating: 1800}
+	chanProblems, err := GetProblemset(filter, 2)
+	if err != nil {
+		t.Fatalf("GetProblemset() error = %v", err)
+	}
+
+	pages := 0
+	for problems := range chanProblems {
+		pages++
+		if len(problems) == 0 {
+			t.Errorf("GetProblemset() page %v is empty", pages)
+		}
+		for _, problem := range problems {
+			if !filter.match(problem) || problem.Arg.Contest == "" || problem.Name == "" {
+				t.Errorf("GetProblemset() = %v, want match of filter %v", problem, filter)
+			}
+		}
+	}
+	if pages != 2 {
+		t.Errorf("GetProblemset() pages = %v, want %v", pages, 2)
+	}
+}
@@ -0,0 +1,253 @@
+package codeforces
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/go-rod/rod"
+)
+
+// RegisterOptions holds choices of the registration form.
+type RegisterOptions struct {
+	// Unrated registers for unrated participation. Supported
+	// only in contests allowing the choice (like Div. 3/4).
+	Unrated bool
+	// Team is the name of the team to register as.
+	// Registers as an individual if empty.
+	Team string
+}
+
+// checkRegister returns an error if the registration form
+// in the page can't be completed with the given choices.
+func checkRegister(pd *goquery.Document, opts RegisterOptions) error {
+	form := pd.Find(`form.contestRegistrationForm`)
+	if form.Length() == 0 {
+		return ErrRegistrationClosed
+	}
+
+	if opts.Team != "" && getTeamValue(pd, opts.Team) == "" {
+		return fmt.Errorf("team %v not found", opts.Team)
+	}
+
+	if opts.Unrated && form.Find(`input[name="ratedOrNot"][value="unrated"]`).Length() == 0 {
+		return fmt.Errorf("unrated participation not allowed")
+	}
+
+	return nil
+}
+
+// getTeamValue returns the value of the option (in the
+// registration form) of the team named exactly so. Returns
+// an empty string if there is no such team.
+func getTeamValue(pd *goquery.Document, team string) string {
+	value := ""
+	pd.Find(`form.contestRegistrationForm select[name="takePartAs"]>option`).Each(func(_ int, option *goquery.Selection) {
+		if value == "" && clean(option.Text()) == team {
+			value = option.AttrOr("value", "")
+		}
+	})
+	return value
+}
+
+// getUnregisterLink returns the link to cancel the registration
+// in the contest, from the contests page. Returns an empty
+// string if the registration can't be cancelled.
+func getUnregisterLink(pd *goquery.Document, arg Args) string {
+	row := pd.Find(fmt.Sprintf(`tr[data-contestid="%v"]`, arg.Contest))
+	return row.Find(`a[href*="/unregister"]`).AttrOr("href", "")
+}
+
+// getRegStatus returns the registration status
+// of the contest, from the contests page.
+func getRegStatus(pd *goquery.Document, arg Args) (int, error) {
+	contests, err := getContests(pd, arg)
+	if err != nil {
+		return RegistrationNotExists, err
+	}
+	if len(contests) == 0 {
+		return RegistrationNotExists, ErrContestNotFound
+	}
+	return contests[0].RegStatus, nil
+}
+
+// Register registers for the contest, completing the registration
+// form with the given choices, and returns the updated registration
+// status (see Contest.RegStatus). Registration is supported only
+// in contests (not gyms/groups).
+//
+// ErrRegistrationClosed is returned if registration isn't open.
+// If already registered, RegistrationDone is returned as is.
+func (arg Args) Register(opts RegisterOptions) (int, error) {
+	return DefaultClient.Register(arg, opts)
+}
+
+// RegisterContext is the same as Register, using the given context.
+func (arg Args) RegisterContext(ctx context.Context, opts RegisterOptions) (int, error) {
+	return DefaultClient.RegisterContext(ctx, arg, opts)
+}
+
+// Unregister cancels the registration in the contest, and
+// returns the updated registration status. Does nothing if
+// not registered.
+//
+// ErrRegistrationClosed is returned if the registration
+// can't be cancelled anymore.
+func (arg Args) Unregister() (int, error) {
+	return DefaultClient.Unregister(arg)
+}
+
+// UnregisterContext is the same as Unregister, using the given context.
+func (arg Args) UnregisterContext(ctx context.Context) (int, error) {
+	return DefaultClient.UnregisterContext(ctx, arg)
+}
+
+// Register registers for the contest.
+// See Args.Register for more details.
+func (c *Client) Register(arg Args, opts RegisterOptions) (int, error) {
+	return c.RegisterContext(context.Background(), arg, opts)
+}
+
+// RegisterContext is the same as Register, using the given context.
+func (c *Client) RegisterContext(ctx context.Context, arg Args, opts RegisterOptions) (int, error) {
+	status, err := c.regStatus(ctx, arg)
+	if err != nil || status != RegistrationOpen {
+		if err == nil && status != RegistrationDone {
+			err = ErrRegistrationClosed
+		}
+		return status, err
+	}
+
+	link, err := c.RegisterPage(arg)
+	if err != nil {
+		return status, err
+	}
+
+	p, err := c.loadPage(ctx, link)
+	if err != nil {
+		return status, err
+	}
+	defer p.Close()
+
+	if err := p.waitFor(link, `#footer`); err != nil {
+		return status, err
+	}
+
+	pd := p.parse()
+	if err := checkRegister(pd, opts); err != nil {
+		return status, err
+	}
+
+	// All cases have been handled. Complete the form.
+	if err := rod.Try(func() {
+		form := p.MustElement(`form.contestRegistrationForm`)
+		if opts.Team != "" {
+			// Select the team by value; names may be substrings of others.
+			mustSelectValue(form.MustElement(`select[name="takePartAs"]`), getTeamValue(pd, opts.Team))
+		}
+		if opts.Unrated {
+			form.MustElement(`input[name="ratedOrNot"][value="unrated"]`).MustClick()
+		}
+		if elm, err := form.Element(`input[name="termsAgreed"]`); err == nil &&
+			!elm.MustProperty("checked").Bool() {
+			elm.MustClick()
+		}
+		form.MustElement(`input.submit`).MustClick().WaitInvisible()
+	}); err != nil {
+		return status, err
+	}
+
+	// Redirected to the contests page on success.
+	if _, err := p.Race().Element(`.error`).Handle(handleErrMsg).
+		Element(`tr[data-contestid]`).Do(); err != nil {
+		return status, err
+	}
+
+	return getRegStatus(p.parse(), arg)
+}
+
+// Unregister cancels the registration in the contest.
+// See Args.Unregister for more details.
+func (c *Client) Unregister(arg Args) (int, error) {
+	return c.UnregisterContext(context.Background(), arg)
+}
+
+// UnregisterContext is the same as Unregister, using the given context.
+func (c *Client) UnregisterContext(ctx context.Context, arg Args) (int, error) {
+	if arg.Class != ClassContest {
+		return RegistrationNotExists, ErrInvalidSpecifier
+	}
+
+	link, err := c.ContestsPage(arg)
+	if err != nil {
+		return RegistrationNotExists, err
+	}
+
+	p, err := c.loadPage(ctx, link)
+	if err != nil {
+		return RegistrationNotExists, err
+	}
+	defer p.Close()
+
+	if err := p.waitFor(link, `#footer`); err != nil {
+		return RegistrationNotExists, err
+	}
+
+	pd := p.parse()
+	status, err := getRegStatus(pd, arg)
+	if err != nil || status != RegistrationDone {
+		return status, err
+	}
+
+	href := getUnregisterLink(pd, arg)
+	if href == "" {
+		return status, ErrRegistrationClosed
+	}
+
+	if !strings.HasPrefix(href, "http") {
+		href = c.Host() + href
+	}
+	if err := p.Navigate(href); err != nil {
+		return status, err
+	}
+	p.WaitLoad()
+
+	// Load the contests page anew, to check the registration.
+	if err := p.Navigate(link); err != nil {
+		return status, err
+	}
+	if err := p.waitFor(link, `tr[data-contestid]`); err != nil {
+		return status, err
+	}
+
+	if status, err = getRegStatus(p.parse(), arg); err == nil && status == RegistrationDone {
+		// Registration can't be cancelled anymore.
+		err = ErrRegistrationClosed
+	}
+	return status, err
+}
+
+// regStatus returns the registration status of the contest.
+func (c *Client) regStatus(ctx context.Context, arg Args) (int, error) {
+	if arg.Class != ClassContest {
+		return RegistrationNotExists, ErrInvalidSpecifier
+	}
+
+	link, err := c.ContestsPage(arg)
+	if err != nil {
+		return RegistrationNotExists, err
+	}
+
+	p, err := c.loadPage(ctx, link)
+	if err != nil {
+		return RegistrationNotExists, err
+	}
+	defer p.Close()
+
+	if err := p.waitFor(link, `#footer`); err != nil {
+		return RegistrationNotExists, err
+	}
+
+	return getRegStatus(p.parse(), arg)
+}
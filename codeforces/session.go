@@ -0,0 +1,225 @@
+package codeforces
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/go-rod/rod"
+)
+
+// LoginOptions holds choices of the login form.
+type LoginOptions struct {
+	// Remember keeps the session alive across restarts of
+	// the browser (when a user data directory is used).
+	Remember bool
+}
+
+func getCurrentUser(pd *goquery.Document) string {
+	return strings.TrimSpace(pd.Find(`#header a[href^="/profile/"]`).First().Text())
+}
+
+// getLoginResult returns the handle of the logged in user, or the
+// error shown in the login form (in the page), if login failed.
+func getLoginResult(pd *goquery.Document) (string, error) {
+	if handle := getCurrentUser(pd); handle != "" {
+		return handle, nil
+	}
+
+	// Example error message: "Invalid handle/email or password"
+	msg := clean(pd.Find(`#enterForm .error`).Text())
+	return "", &SiteError{Err: ErrInvalidCredentials, Msg: msg}
+}
+
+// Login logs in to the website with the given credentials, and
+// returns the handle of the logged in user. The session is
+// remembered, and is thus persisted in the user data directory.
+// Uses DefaultClient; see Client.Login.
+func Login(handleOrEmail, password string) (string, error) {
+	return DefaultClient.Login(handleOrEmail, password)
+}
+
+// LoginContext is the same as Login, using the given context.
+func LoginContext(ctx context.Context, handleOrEmail, password string) (string, error) {
+	return DefaultClient.LoginContext(ctx, handleOrEmail, password)
+}
+
+// LoginWithOptions is the same as Login, using the given choices
+// of the login form. Uses DefaultClient; see Client.LoginWithOptions.
+func LoginWithOptions(handleOrEmail, password string, opts LoginOptions) (string, error) {
+	return DefaultClient.LoginWithOptions(handleOrEmail, password, opts)
+}
+
+// LoginWithOptionsContext is the same as LoginWithOptions, using the given context.
+func LoginWithOptionsContext(ctx context.Context, handleOrEmail, password string, opts LoginOptions) (string, error) {
+	return DefaultClient.LoginWithOptionsContext(ctx, handleOrEmail, password, opts)
+}
+
+// Logout logs out the current user session, if any.
+// Uses DefaultClient; see Client.Logout.
+func Logout() error {
+	return DefaultClient.Logout()
+}
+
+// LogoutContext is the same as Logout, using the given context.
+func LogoutContext(ctx context.Context) error {
+	return DefaultClient.LogoutContext(ctx)
+}
+
+// CurrentUser returns the handle of the logged in user.
+// Uses DefaultClient; see Client.CurrentUser.
+func CurrentUser() (string, error) {
+	return DefaultClient.CurrentUser()
+}
+
+// CurrentUserContext is the same as CurrentUser, using the given context.
+func CurrentUserContext(ctx context.Context) (string, error) {
+	return DefaultClient.CurrentUserContext(ctx)
+}
+
+// Login logs in to the website with the given credentials, and
+// returns the handle of the logged in user. 'Remember me' is
+// checked, so the session persists across restarts of the
+// browser (when a user data directory is used). Use
+// LoginWithOptions to leave it unchecked.
+//
+// If a user (with the given handle) is already logged in, the
+// handle is returned as is. If a different user is logged in,
+// the user is logged out first.
+//
+// ErrInvalidCredentials is returned (as a SiteError, holding the
+// message shown by the website) if the credentials are rejected.
+func (c *Client) Login(handleOrEmail, password string) (string, error) {
+	return c.LoginContext(context.Background(), handleOrEmail, password)
+}
+
+// LoginContext is the same as Login, using the given context.
+func (c *Client) LoginContext(ctx context.Context, handleOrEmail, password string) (string, error) {
+	return c.LoginWithOptionsContext(ctx, handleOrEmail, password, LoginOptions{Remember: true})
+}
+
+// LoginWithOptions is the same as Login, only 'Remember me'
+// is checked as set in opts. See Login for more details.
+func (c *Client) LoginWithOptions(handleOrEmail, password string, opts LoginOptions) (string, error) {
+	return c.LoginWithOptionsContext(context.Background(), handleOrEmail, password, opts)
+}
+
+// LoginWithOptionsContext is the same as LoginWithOptions, using the given context.
+func (c *Client) LoginWithOptionsContext(ctx context.Context, handleOrEmail, password string, opts LoginOptions) (string, error) {
+	if handleOrEmail == "" || password == "" {
+		return "", ErrInvalidCredentials
+	}
+
+	handle, err := c.CurrentUserContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if handle != "" {
+		if strings.EqualFold(handle, handleOrEmail) {
+			return handle, nil
+		}
+		// Can't verify if the email belongs to the
+		// current user, so start a new session anyway.
+		if err := c.LogoutContext(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	link := fmt.Sprintf("%v/enter", c.Host())
+	p, err := c.loadPage(ctx, link)
+	if err != nil {
+		return "", err
+	}
+	defer p.Close()
+
+	if err := p.waitFor(link, `#handleOrEmail`); err != nil {
+		return "", err
+	}
+
+	if err := rod.Try(func() {
+		p.MustElement(`#handleOrEmail`).MustInput(handleOrEmail)
+		p.MustElement(`#password`).MustInput(password)
+		if p.MustElement(`#remember`).MustProperty("checked").Bool() != opts.Remember {
+			p.MustElement(`#remember`).MustClick()
+		}
+		p.MustElement(`.submit`).MustClick().WaitInvisible()
+	}); err != nil {
+		return "", err
+	}
+
+	if _, err := p.Race().Element(`#enterForm .error`).
+		Element(`#header a[href^="/profile/"]`).Do(); err != nil {
+		return "", err
+	}
+
+	handle, err = getLoginResult(p.parse())
+	if err == nil {
+		c.syncSession(p)
+	}
+	return handle, err
+}
+
+// Logout logs out the current user session. Does
+// nothing if no user is logged in.
+func (c *Client) Logout() error {
+	return c.LogoutContext(context.Background())
+}
+
+// LogoutContext is the same as Logout, using the given context.
+func (c *Client) LogoutContext(ctx context.Context) error {
+	link := c.Host() + "/"
+	p, err := c.loadPage(ctx, link)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+
+	if err := p.waitFor(link, `#footer`); err != nil {
+		return err
+	}
+
+	// Logout link is of the form '/<csrf-token>/logout'.
+	href, exists := p.parse().Find(`#header a[href$="/logout"]`).Attr("href")
+	if !exists {
+		return nil
+	}
+
+	// Redirects to the home page once logged out.
+	if err := p.Navigate(c.Host() + href); err != nil {
+		return err
+	}
+	if err := p.waitFor(link, `#header a[href^="/enter"]`); err != nil {
+		return err
+	}
+
+	c.syncSession(p)
+	return nil
+}
+
+// syncSession copies the session (cookies) in the browser to
+// the client fetching pages over plain HTTP (if any), so that
+// pages are fetched as the same user the browser is logged in.
+func (c *Client) syncSession(p *page) {
+	if c != nil && c.web != nil {
+		c.web.setCookies(p.cookies())
+	}
+}
+
+// CurrentUser returns the handle of the logged in user.
+// Returns an empty string if no user is logged in.
+func (c *Client) CurrentUser() (string, error) {
+	return c.CurrentUserContext(context.Background())
+}
+
+// CurrentUserContext is the same as CurrentUser, using the given context.
+func (c *Client) CurrentUserContext(ctx context.Context) (string, error) {
+	link := c.Host() + "/"
+	pd, err := c.fetch(ctx, link, `#footer`)
+	if err != nil {
+		return "", err
+	}
+
+	return getCurrentUser(pd), nil
+}
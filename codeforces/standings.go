@@ -0,0 +1,481 @@
+package codeforces
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/go-rod/rod"
+)
+
+type (
+	// StandingsOptions holds filters of the standings.
+	StandingsOptions struct {
+		// Unofficial includes unofficial participants
+		// (virtual, out of competition, practice).
+		Unofficial bool
+		// FriendsOnly includes only friends of the user.
+		FriendsOnly bool
+		// Room includes only participants of the room.
+		// Supported only in contests (not gyms/groups).
+		Room string
+	}
+
+	// ProblemResult holds result of a party in a problem.
+	ProblemResult struct {
+		Problem string
+		Points  float64
+		// Accepted is set if the problem is solved.
+		// AcceptedAt is the time (since contest start)
+		// of the accepted submission, if shown.
+		Accepted   bool
+		AcceptedAt time.Duration
+		// Rejected is the number of rejected attempts.
+		Rejected int
+		// FailedSystemTest is set if the solution
+		// passed pretests, but not system tests.
+		FailedSystemTest bool
+	}
+
+	// StandingsRow holds details of a party in the standings.
+	StandingsRow struct {
+		Rank int
+		// Team is the team name, if the party is a team.
+		Team    string
+		Members []string
+		// Unofficial is set for unofficial participants.
+		Unofficial bool
+		Points     float64
+		Penalty    int
+		// Hacks made by the party (not per problem,
+		// as the website doesn't report them so).
+		SuccessfulHacks   int
+		UnsuccessfulHacks int
+		Results           []ProblemResult
+	}
+)
+
+// parseClock parses elapsed time strings ('hh:mm' or 'hh:mm:ss').
+func parseClock(str string) time.Duration {
+	var vals []int
+	for _, v := range strings.Split(clean(str), ":") {
+		val, err := strconv.Atoi(v)
+		if err != nil {
+			return 0
+		}
+		vals = append(vals, val)
+	}
+
+	switch len(vals) {
+	case 2:
+		return time.Duration(vals[0])*time.Hour + time.Duration(vals[1])*time.Minute
+	case 3:
+		return time.Duration(vals[0])*time.Hour + time.Duration(vals[1])*time.Minute +
+			time.Duration(vals[2])*time.Second
+	}
+	return 0
+}
+
+func getStandings(pd *goquery.Document) ([]StandingsRow, error) {
+	rows := make([]StandingsRow, 0)
+	reNum := regexp.MustCompile(`\d+(?:\.\d+)?`)
+
+	// Column index => kind of column (or problem index).
+	columns := make(map[int]string)
+	pd.Find(`table.standings tr`).First().Find(`th`).Each(func(i int, cell *goquery.Selection) {
+		if link := cell.Find(`a[href*="/problem/"]`); link.Length() > 0 {
+			columns[i] = "problem:" + strings.ToLower(clean(link.Text()))
+			return
+		}
+
+		switch strings.ToLower(clean(cell.Text())) {
+		case "#":
+			columns[i] = "rank"
+		case "who", "кто":
+			columns[i] = "who"
+		case "=":
+			columns[i] = "points"
+		case "*":
+			columns[i] = "hacks"
+		case "penalty", "штраф":
+			columns[i] = "penalty"
+		}
+	})
+
+	pd.Find(`table.standings tr[participantid]`).Each(func(_ int, tr *goquery.Selection) {
+		var row StandingsRow
+
+		tr.Find(`td`).Each(func(i int, cell *goquery.Selection) {
+			kind := columns[i]
+			switch {
+			case kind == "rank":
+				if rank := reNum.FindString(cell.Text()); rank != "" {
+					row.Rank, _ = strconv.Atoi(rank)
+				} else {
+					row.Unofficial = true
+				}
+
+			case kind == "who":
+				if team := cell.Find(`a[href*="/team/"]`); team.Length() > 0 {
+					row.Team = clean(team.First().Text())
+				}
+				cell.Find(`a[href^="/profile/"]`).Each(func(_ int, member *goquery.Selection) {
+					row.Members = append(row.Members, clean(member.Text()))
+				})
+				// Virtual/out of competition participants are marked.
+				if cell.Find(`sup`).Length() > 0 {
+					row.Unofficial = true
+				}
+
+			case kind == "points":
+				row.Points, _ = strconv.ParseFloat(reNum.FindString(cell.Text()), 64)
+
+			case kind == "penalty":
+				row.Penalty, _ = strconv.Atoi(reNum.FindString(cell.Text()))
+
+			case kind == "hacks":
+				row.SuccessfulHacks, _ = strconv.Atoi(reNum.FindString(cell.Find(`.successfulChallengeCount`).Text()))
+				row.UnsuccessfulHacks, _ = strconv.Atoi(reNum.FindString(cell.Find(`.failedChallengeCount`).Text()))
+
+			case strings.HasPrefix(kind, "problem:"):
+				result := ProblemResult{Problem: strings.TrimPrefix(kind, "problem:")}
+
+				if acc := cell.Find(`.cell-accepted, .cell-passed-system-test`); acc.Length() > 0 {
+					result.Accepted = true
+					str := clean(acc.First().Text())
+					if strings.HasPrefix(str, "+") {
+						// ICPC format: '+' or '+<rejected attempts>'.
+						result.Rejected, _ = strconv.Atoi(reNum.FindString(str))
+						result.Points = 1
+					} else {
+						result.Points, _ = strconv.ParseFloat(reNum.FindString(str), 64)
+					}
+					result.AcceptedAt = parseClock(cell.Find(`.cell-time`).Text())
+				} else if rej := cell.Find(`.cell-rejected, .cell-failed-system-test`); rej.Length() > 0 {
+					result.Rejected, _ = strconv.Atoi(reNum.FindString(rej.First().Text()))
+					result.FailedSystemTest = rej.HasClass("cell-failed-system-test")
+				}
+
+				row.Results = append(row.Results, result)
+			}
+		})
+
+		rows = append(rows, row)
+	})
+
+	return rows, nil
+}
+
+// getStandingsPageCount returns the number of pages of the standings.
+func getStandingsPageCount(pd *goquery.Document) int {
+	count := 1
+	pd.Find(`.page-index[pageindex]`).Each(func(_ int, sel *goquery.Selection) {
+		if val, err := strconv.Atoi(sel.AttrOr("pageindex", "")); err == nil && val > count {
+			count = val
+		}
+	})
+	return count
+}
+
+// GetStandings returns standings of the contest, page by page.
+// Filters (unofficial participants, friends, room) are applied
+// as specified in opts.
+//
+// Set pageCount to maximum number of pages to parse. Each page
+// consists of (at most) 200 rows of data.
+func (arg Args) GetStandings(pageCount uint, opts StandingsOptions) (<-chan []StandingsRow, error) {
+	return DefaultClient.GetStandings(arg, pageCount, opts)
+}
+
+// GetStandingsContext is the same as GetStandings, using the given context.
+func (arg Args) GetStandingsContext(ctx context.Context, pageCount uint, opts StandingsOptions) (<-chan []StandingsRow, error) {
+	return DefaultClient.GetStandingsContext(ctx, arg, pageCount, opts)
+}
+
+// GetStandings returns standings of the contest, page by page.
+// See Args.GetStandings for more details.
+func (c *Client) GetStandings(arg Args, pageCount uint, opts StandingsOptions) (<-chan []StandingsRow, error) {
+	return c.GetStandingsContext(context.Background(), arg, pageCount, opts)
+}
+
+// GetStandingsContext is the same as GetStandings, using the given context.
+//
+// Once ctx is done, parsing is stopped, the browser
+// tab is closed and the returned channel is closed.
+func (c *Client) GetStandingsContext(ctx context.Context, arg Args, pageCount uint, opts StandingsOptions) (<-chan []StandingsRow, error) {
+	// Friends of the user are known only to the website.
+	if c != nil && c.api != nil && !opts.FriendsOnly {
+		return c.api.pageStandings(ctx, arg, pageCount, opts)
+	}
+
+	if c != nil && c.web != nil {
+		return c.web.pageStandings(ctx, pageCount, func(page int) (string, error) {
+			return c.standingsPage(arg, opts, page)
+		})
+	}
+
+	link, err := c.standingsPage(arg, opts, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := c.loadPage(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.waitFor(link, `table.standings`); err != nil {
+		p.Close()
+		return nil, err
+	}
+
+	chanStandings := make(chan []StandingsRow)
+	go func() {
+		defer p.Close()
+		defer close(chanStandings)
+
+		// Must methods panic once the context is done.
+		rod.Try(func() {
+			for page := 1; uint(page) <= pageCount; page++ {
+				pd := p.parse()
+				// Ignore error, write whatever is parsed.
+				rows, _ := getStandings(pd)
+				select {
+				case chanStandings <- rows:
+				case <-ctx.Done():
+					return
+				}
+
+				if page >= getStandingsPageCount(pd) {
+					// All pages parsed.
+					break
+				}
+
+				link, _ := c.standingsPage(arg, opts, page+1)
+				p.MustNavigate(link).MustElement(`table.standings`)
+				p.WaitLoad()
+			}
+		})
+	}()
+
+	return chanStandings, nil
+}
+
+type (
+	// StandingsEvent is a change in the standings,
+	// between two consecutive refreshes.
+	StandingsEvent struct {
+		Type int
+		// Party is the team name (or handles, comma
+		// separated, if not a team) of the party.
+		Party string
+		// Problem (and At) are set for accepts.
+		Problem string
+		At      time.Duration
+		// OldRank (and NewRank) are set for rank changes.
+		// OldRank is 0 if the party was not present before.
+		OldRank int
+		NewRank int
+	}
+
+	// StandingsUpdate holds the refreshed standings,
+	// and the changes since the previous refresh.
+	StandingsUpdate struct {
+		Rows   []StandingsRow
+		Events []StandingsEvent
+	}
+)
+
+// Different values of 'Type' of StandingsEvent.
+const (
+	EventRankChange = iota + 1
+	EventAccepted
+	EventFirstToSolve
+)
+
+// party returns the name of the party of the row.
+func (row StandingsRow) party() string {
+	if row.Team != "" {
+		return row.Team
+	}
+	return strings.Join(row.Members, ", ")
+}
+
+// getStandingsEvents returns the changes between the standings
+// prev and cur. solved holds problems solved by any party in
+// prev, and is updated with the problems solved in cur.
+func getStandingsEvents(prev, cur []StandingsRow, solved map[string]bool) []StandingsEvent {
+	events := make([]StandingsEvent, 0)
+
+	prevRows := make(map[string]StandingsRow)
+	for _, row := range prev {
+		prevRows[row.party()] = row
+	}
+
+	// Problem => index (in events) of the first accept.
+	firstSolve := make(map[string]int)
+	for _, row := range cur {
+		old, ok := prevRows[row.party()]
+		if row.Rank != old.Rank && !row.Unofficial {
+			events = append(events, StandingsEvent{
+				Type:    EventRankChange,
+				Party:   row.party(),
+				OldRank: old.Rank,
+				NewRank: row.Rank,
+			})
+		}
+
+		for i, result := range row.Results {
+			if !result.Accepted || (ok && i < len(old.Results) && old.Results[i].Accepted) {
+				continue
+			}
+
+			events = append(events, StandingsEvent{
+				Type:    EventAccepted,
+				Party:   row.party(),
+				Problem: result.Problem,
+				At:      result.AcceptedAt,
+			})
+
+			if solved[result.Problem] {
+				continue
+			}
+			if j, ok := firstSolve[result.Problem]; !ok || result.AcceptedAt < events[j].At {
+				firstSolve[result.Problem] = len(events) - 1
+			}
+		}
+	}
+
+	// Keep order of events deterministic.
+	var indices []int
+	for problem, i := range firstSolve {
+		indices = append(indices, i)
+		solved[problem] = true
+	}
+	sort.Ints(indices)
+
+	for _, i := range indices {
+		event := events[i]
+		event.Type = EventFirstToSolve
+		events = append(events, event)
+	}
+
+	return events
+}
+
+// isContestOver reports if the contest of the standings has ended.
+// If the phase of the contest isn't shown in the page, the contest
+// is over once end (ignored if zero) has passed.
+func isContestOver(pd *goquery.Document, end time.Time) bool {
+	phaseSel := pd.Find(`.contest-state-phase`)
+	if phaseSel.Length() == 0 {
+		return !end.IsZero() && !time.Now().Before(end)
+	}
+
+	phase := strings.ToLower(clean(phaseSel.Text()))
+	return phase == "finished" || phase == "закончено"
+}
+
+// GetLiveStandings returns the standings of a running contest,
+// refreshed every interval (10 seconds, if not positive), till
+// the contest is over. Only the first page of the standings is
+// parsed. Filters are applied as specified in opts.
+//
+// The first update holds no events; each subsequent update holds
+// all rank changes, new accepts and first solves of problems since
+// the previous update.
+func (arg Args) GetLiveStandings(interval time.Duration, opts StandingsOptions) (<-chan StandingsUpdate, error) {
+	return DefaultClient.GetLiveStandings(arg, interval, opts)
+}
+
+// GetLiveStandingsContext is the same as GetLiveStandings, using the given context.
+func (arg Args) GetLiveStandingsContext(ctx context.Context, interval time.Duration, opts StandingsOptions) (<-chan StandingsUpdate, error) {
+	return DefaultClient.GetLiveStandingsContext(ctx, arg, interval, opts)
+}
+
+// GetLiveStandings returns the standings of a running contest.
+// See Args.GetLiveStandings for more details.
+func (c *Client) GetLiveStandings(arg Args, interval time.Duration, opts StandingsOptions) (<-chan StandingsUpdate, error) {
+	return c.GetLiveStandingsContext(context.Background(), arg, interval, opts)
+}
+
+// GetLiveStandingsContext is the same as GetLiveStandings, using the given context.
+//
+// Once ctx is done, polling is stopped, the browser
+// tab is closed and the returned channel is closed.
+func (c *Client) GetLiveStandingsContext(ctx context.Context, arg Args, interval time.Duration, opts StandingsOptions) (<-chan StandingsUpdate, error) {
+	if interval <= 0 {
+		interval = time.Second * 10
+	}
+
+	link, err := c.standingsPage(arg, opts, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := c.loadPage(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.waitFor(link, `table.standings`); err != nil {
+		p.Close()
+		return nil, err
+	}
+
+	// Fall back to the end time of the contest,
+	// if the phase of the contest isn't shown.
+	var end time.Time
+	if p.parse().Find(`.contest-state-phase`).Length() == 0 {
+		if _, end, err = c.contestPeriod(ctx, arg); err != nil {
+			p.Close()
+			return nil, err
+		}
+	}
+
+	chanUpdates := make(chan StandingsUpdate)
+	go func() {
+		defer p.Close()
+		defer close(chanUpdates)
+
+		var prev []StandingsRow
+		solved := make(map[string]bool)
+		for first := true; ; first = false {
+			pd := p.parse()
+			rows, _ := getStandings(pd)
+
+			events := getStandingsEvents(prev, rows, solved)
+			if first {
+				// Nothing has changed yet.
+				events = []StandingsEvent{}
+			}
+			prev = rows
+
+			select {
+			case chanUpdates <- StandingsUpdate{Rows: rows, Events: events}:
+			case <-ctx.Done():
+				return
+			}
+
+			if isContestOver(pd, end) {
+				break
+			}
+
+			// Wait for atleast interval before parsing again.
+			timer := time.Now()
+			if err := p.Reload(); err != nil {
+				break
+			}
+			p.WaitLoad()
+			if !p.sleep(interval - time.Since(timer)) {
+				break
+			}
+		}
+	}()
+
+	return chanUpdates, nil
+}
@@ -0,0 +1,177 @@
+package codeforces
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_getStandingsEvents(t *testing.T) {
+	prev := []StandingsRow{
+		{
+			Rank:    1,
+			Members: []string{"tourist"},
+			Results: []ProblemResult{
+				{Problem: "a", Accepted: true, AcceptedAt: time.Minute * 4},
+				{Problem: "b"},
+				{Problem: "c"},
+			},
+		},
+		{
+			Rank:    2,
+			Members: []string{"Petr"},
+			Results: []ProblemResult{
+				{Problem: "a"},
+				{Problem: "b"},
+				{Problem: "c"},
+			},
+		},
+	}
+	cur := []StandingsRow{
+		{
+			Rank:    1,
+			Members: []string{"Petr"},
+			Results: []ProblemResult{
+				{Problem: "a", Accepted: true, AcceptedAt: time.Minute * 10},
+				{Problem: "b", Accepted: true, AcceptedAt: time.Minute * 12},
+				{Problem: "c"},
+			},
+		},
+		{
+			Rank:    2,
+			Members: []string{"tourist"},
+			Results: []ProblemResult{
+				{Problem: "a", Accepted: true, AcceptedAt: time.Minute * 4},
+				{Problem: "b", Accepted: true, AcceptedAt: time.Minute * 11},
+				{Problem: "c"},
+			},
+		},
+		{
+			Rank:    3,
+			Team:    "Team",
+			Members: []string{"cp-tools"},
+			Results: []ProblemResult{
+				{Problem: "a"},
+				{Problem: "b"},
+				{Problem: "c"},
+			},
+		},
+	}
+
+	solved := map[string]bool{"a": true}
+	want := []StandingsEvent{
+		{Type: EventRankChange, Party: "Petr", OldRank: 2, NewRank: 1},
+		{Type: EventAccepted, Party: "Petr", Problem: "a", At: time.Minute * 10},
+		{Type: EventAccepted, Party: "Petr", Problem: "b", At: time.Minute * 12},
+		{Type: EventRankChange, Party: "tourist", OldRank: 1, NewRank: 2},
+		{Type: EventAccepted, Party: "tourist", Problem: "b", At: time.Minute * 11},
+		{Type: EventRankChange, Party: "Team", OldRank: 0, NewRank: 3},
+		{Type: EventFirstToSolve, Party: "tourist", Problem: "b", At: time.Minute * 11},
+	}
+
+	if got := getStandingsEvents(prev, cur, solved); !reflect.DeepEqual(got, want) {
+		t.Errorf("getStandingsEvents() = %+v, want %+v", got, want)
+	}
+	if !solved["b"] || solved["c"] {
+		t.Errorf("getStandingsEvents() solved = %v, want a and b", solved)
+	}
+
+	// Nothing changed.
+	if got := getStandingsEvents(cur, cur, solved); len(got) != 0 {
+		t.Errorf("getStandingsEvents() = %+v, want none", got)
+	}
+}
+
+func TestArgs_GetStandings(t *testing.T) {
+	skipOffline(t)
+	time.Sleep(time.Second * 10)
+
+	tests := []struct {
+		name      string
+		arg       Args
+		pageCount uint
+		opts      StandingsOptions
+		wantPages int
+		wantErr   bool
+	}{
+		{
+			name:      "Test #1",
+			arg:       Args{"4", "", "contest", ""},
+			pageCount: 2,
+			opts:      StandingsOptions{},
+			wantPages: 2,
+			wantErr:   false,
+		},
+		{
+			name:      "Test #2",
+			arg:       Args{"100499", "", "gym", ""},
+			pageCount: 1,
+			opts:      StandingsOptions{Unofficial: true},
+			wantPages: 1,
+			wantErr:   false,
+		},
+		{
+			name:      "Test #3",
+			arg:       Args{"12345", "", "contest", ""},
+			pageCount: 1,
+			opts:      StandingsOptions{},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.arg.GetStandings(tt.pageCount, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Args.GetStandings() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				// No data is returned; continue.
+				return
+			}
+
+			pages, prevRank := 0, 0
+			for rows := range got {
+				pages++
+				for _, row := range rows {
+					if len(row.Members) == 0 {
+						t.Errorf("Args.GetStandings() row has no members: %+v", row)
+					}
+					if !row.Unofficial && row.Rank < prevRank {
+						t.Errorf("Args.GetStandings() rank %v after rank %v", row.Rank, prevRank)
+					}
+					if !row.Unofficial {
+						prevRank = row.Rank
+					}
+				}
+			}
+
+			if pages != tt.wantPages {
+				t.Errorf("Args.GetStandings() pages = %v, want %v", pages, tt.wantPages)
+			}
+		})
+	}
+}
+
+func TestArgs_GetLiveStandings(t *testing.T) {
+	skipOffline(t)
+	time.Sleep(time.Second * 10)
+
+	// Contest is over; only one update is sent.
+	got, err := Args{"4", "", "contest", ""}.GetLiveStandings(time.Second, StandingsOptions{})
+	if err != nil {
+		t.Fatalf("Args.GetLiveStandings() error = %v", err)
+	}
+
+	updates := 0
+	for update := range got {
+		updates++
+		if len(update.Rows) == 0 || len(update.Events) != 0 {
+			t.Errorf("Args.GetLiveStandings() = %v rows, %v events", len(update.Rows), len(update.Events))
+		}
+	}
+	if updates != 1 {
+		t.Errorf("Args.GetLiveStandings() updates = %v, want 1", updates)
+	}
+}
@@ -1,9 +1,15 @@
 package codeforces
 
 import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/go-rod/rod"
 )
 
 type (
@@ -18,8 +24,39 @@ type (
 		VerdictStatus int
 		Time          string
 		Memory        string
-		IsJudging     bool
-		Arg           Args
+		// Parsed values of Time and Memory (in bytes).
+		TimeDuration time.Duration
+		MemoryBytes  int64
+		// Participant is the participation type
+		// (like ParticipantVirtual).
+		Participant int
+		IsJudging   bool
+		Arg         Args
+	}
+)
+
+type (
+	// TestResult holds the judgement of a submission on a test.
+	TestResult struct {
+		Test    int
+		Verdict string
+		Time    time.Duration
+		// Memory used, in bytes.
+		Memory int64
+		// Input, Output and Answer are truncated
+		// by the website, if the test is large.
+		Input      string
+		Output     string
+		Answer     string
+		CheckerLog string
+	}
+
+	// SubmissionDetails holds the judge protocol of a submission.
+	SubmissionDetails struct {
+		Protocol []TestResult
+		// CompilationError is the full compilation error
+		// message, if the verdict is VerdictCE.
+		CompilationError string
 	}
 )
 
@@ -39,11 +76,44 @@ const (
 
 	// Depreciated: Use VerdictAC instead.
 	VerdictPretestPass // Pretests passed
+
+	VerdictPartial  // Partial result
+	VerdictPE       // Presentation Error
+	VerdictFailed   // Judgement Failed
+	VerdictRejected // Rejected
+	VerdictSV       // Security Violated
+	VerdictIPC      // Input Preparation Crashed
 )
 
-func (p *page) getSubmissions(arg Args) ([]Submission, error) {
-	pd := p.parse()
+// Participation types of submissions.
+const (
+	ParticipantContestant = iota
+	ParticipantPractice
+	ParticipantVirtual
+	ParticipantOutOfCompetition
+)
+
+// verdictNames maps verdict names used by the website to status.
+var verdictNames = map[string]int{
+	"OK":                        VerdictAC,
+	"WRONG_ANSWER":              VerdictWA,
+	"RUNTIME_ERROR":             VerdictRTE,
+	"COMPILATION_ERROR":         VerdictCE,
+	"TIME_LIMIT_EXCEEDED":       VerdictTLE,
+	"MEMORY_LIMIT_EXCEEDED":     VerdictMLE,
+	"IDLENESS_LIMIT_EXCEEDED":   VerdictILE,
+	"CRASHED":                   VerdictDOJ,
+	"SKIPPED":                   VerdictSkip,
+	"CHALLENGED":                VerdictHack,
+	"PARTIAL":                   VerdictPartial,
+	"PRESENTATION_ERROR":        VerdictPE,
+	"FAILED":                    VerdictFailed,
+	"REJECTED":                  VerdictRejected,
+	"SECURITY_VIOLATED":         VerdictSV,
+	"INPUT_PREPARATION_CRASHED": VerdictIPC,
+}
 
+func getSubmissions(pd *goquery.Document, arg Args) ([]Submission, error) {
 	submissions := make([]Submission, 0)
 
 	submissionTableRows := pd.Find(`tr[data-submission-id]`)
@@ -64,7 +134,17 @@ func (p *page) getSubmissions(arg Args) ([]Submission, error) {
 				submission.When = parseTime(cell.Text())
 
 			case 2:
-				submission.Who = clean(cell.Text())
+				// Unofficial participations are marked; '#' for
+				// virtual and '*' for out of competition.
+				marker := clean(cell.Find(`sup`).Text())
+				submission.Who = clean(strings.TrimSuffix(clean(cell.Text()), marker))
+
+				switch marker {
+				case "#":
+					submission.Participant = ParticipantVirtual
+				case "*":
+					submission.Participant = ParticipantOutOfCompetition
+				}
 
 			case 3:
 				submission.Problem = clean(cell.Text())
@@ -75,22 +155,9 @@ func (p *page) getSubmissions(arg Args) ([]Submission, error) {
 			case 5:
 				submission.Verdict = clean(cell.Text())
 
-				verdictMap := map[string]int{
-					"OK":                      VerdictAC,
-					"WRONG_ANSWER":            VerdictWA,
-					"RUNTIME_ERROR":           VerdictRTE,
-					"COMPILATION_ERROR":       VerdictCE,
-					"TIME_LIMIT_EXCEEDED":     VerdictTLE,
-					"MEMORY_LIMIT_EXCEEDED":   VerdictMLE,
-					"IDLENESS_LIMIT_EXCEEDED": VerdictILE,
-					"CRASHED":                 VerdictDOJ,
-					"SKIPPED":                 VerdictSkip,
-					"CHALLENGED":              VerdictHack,
-				}
-
 				verdictStatus := cell.Find(`.submissionVerdictWrapper`).
 					AttrOr(`submissionverdict`, ``)
-				if v, ok := verdictMap[verdictStatus]; ok {
+				if v, ok := verdictNames[verdictStatus]; ok {
 					submission.VerdictStatus = v
 					submission.IsJudging = false
 				} else {
@@ -99,9 +166,11 @@ func (p *page) getSubmissions(arg Args) ([]Submission, error) {
 
 			case 6:
 				submission.Time = clean(cell.Text())
+				submission.TimeDuration = parseDuration(submission.Time)
 
 			case 7:
 				submission.Memory = clean(cell.Text())
+				submission.MemoryBytes = parseMemory(submission.Memory)
 			}
 		})
 
@@ -111,6 +180,178 @@ func (p *page) getSubmissions(arg Args) ([]Submission, error) {
 	return submissions, nil
 }
 
+// SubmissionsOptions holds filters of submissions.
+// Filters with zero values are not applied.
+type SubmissionsOptions struct {
+	// Verdicts to include (like VerdictAC). Submissions
+	// being judged are included regardless.
+	Verdicts []int
+	// Family of the language (see Language.Family).
+	Family string
+	// Problem index (like "A") to include. Args.Problem
+	// is used if empty.
+	Problem string
+	// Participants are participation types (like
+	// ParticipantPractice) to include.
+	//
+	// Practice submissions aren't marked by the website; these
+	// are told apart (from ParticipantContestant) by the time of
+	// submission, only if the contest is specified.
+	Participants []int
+	// Since and Until limit the time of submission
+	// to the range [Since, Until).
+	Since time.Time
+	Until time.Time
+}
+
+// match reports whether the submission passes the filters.
+func (opts SubmissionsOptions) match(sub Submission, arg Args) bool {
+	problem := opts.Problem
+	if problem == "" {
+		problem = arg.Problem
+	}
+
+	switch {
+	case len(opts.Verdicts) != 0 && !sub.IsJudging && !containsInt(opts.Verdicts, sub.VerdictStatus):
+		return false
+	case opts.Family != "" && languageFamily(sub.Language) != opts.Family:
+		return false
+	case problem != "" && !strings.EqualFold(problem, sub.Arg.Problem):
+		return false
+	case len(opts.Participants) != 0 && !containsInt(opts.Participants, sub.Participant):
+		return false
+	case !opts.Since.IsZero() && sub.When.Before(opts.Since):
+		return false
+	case !opts.Until.IsZero() && !sub.When.Before(opts.Until):
+		return false
+	}
+	return true
+}
+
+// filter returns submissions passing the filters. Submissions made
+// outside the contest period [start, end) are marked as practice.
+func (opts SubmissionsOptions) filter(submissions []Submission, arg Args, start, end time.Time) []Submission {
+	filtered := make([]Submission, 0)
+	for _, sub := range submissions {
+		if sub.Participant == ParticipantContestant && !start.IsZero() &&
+			(sub.When.Before(start) || !sub.When.Before(end)) {
+			sub.Participant = ParticipantPractice
+		}
+		if opts.match(sub, arg) {
+			filtered = append(filtered, sub)
+		}
+	}
+	return filtered
+}
+
+// invokerFamilies maps the prefix (leading letters) of ids of
+// languages in the status filter form to the language family.
+var invokerFamilies = map[string]string{
+	"c":       "C",
+	"cpp":     "C++",
+	"csharp":  "C#",
+	"d":       "D",
+	"go":      "Go",
+	"haskell": "Haskell",
+	"java":    "Java",
+	"js":      "JavaScript",
+	"kotlin":  "Kotlin",
+	"nodejs":  "JavaScript",
+	"ocaml":   "OCaml",
+	"pascal":  "Pascal",
+	"perl":    "Perl",
+	"php":     "PHP",
+	"pypy":    "Python",
+	"python":  "Python",
+	"ruby":    "Ruby",
+	"rust":    "Rust",
+	"scala":   "Scala",
+}
+
+// getStatusFilter returns values to set in the selects of the
+// status filter form of the page, to apply the filters there.
+// Filters not set in opts are reset to the first ('any') option.
+// Returns nil if the form is absent, or is already set so.
+func getStatusFilter(pd *goquery.Document, arg Args, opts SubmissionsOptions) map[string]string {
+	form := pd.Find(`form.status-filter`)
+	if form.Length() == 0 {
+		return nil
+	}
+
+	problem := opts.Problem
+	if problem == "" {
+		problem = arg.Problem
+	}
+	verdict := ""
+	if len(opts.Verdicts) == 1 {
+		for name, status := range verdictNames {
+			if status == opts.Verdicts[0] {
+				verdict = name
+			}
+		}
+	}
+
+	// Languages are listed by compiler; the family can be
+	// selected only if it has a single compiler listed.
+	rxPrefix := regexp.MustCompile(`^[a-z]+`)
+	invoker := ""
+	if opts.Family != "" {
+		count := 0
+		form.Find(`select[name="programTypeForInvoker"]>option`).Each(func(_ int, option *goquery.Selection) {
+			id := option.AttrOr("value", "")
+			if invokerFamilies[rxPrefix.FindString(id)] == opts.Family {
+				invoker, count = id, count+1
+			}
+		})
+		if count != 1 {
+			invoker = ""
+		}
+	}
+
+	matchers := map[string]func(option *goquery.Selection) bool{
+		"frameProblemIndex": func(option *goquery.Selection) bool {
+			return problem != "" && strings.EqualFold(option.AttrOr("value", ""), problem)
+		},
+		"verdictName": func(option *goquery.Selection) bool {
+			return verdict != "" && option.AttrOr("value", "") == verdict
+		},
+		"programTypeForInvoker": func(option *goquery.Selection) bool {
+			return invoker != "" && option.AttrOr("value", "") == invoker
+		},
+	}
+
+	values, changed := make(map[string]string), false
+	for name, match := range matchers {
+		options := form.Find(fmt.Sprintf(`select[name="%v"]>option`, name))
+		if options.Length() == 0 {
+			continue
+		}
+
+		// The first option matches anything.
+		value := options.First().AttrOr("value", "")
+		options.EachWithBreak(func(_ int, option *goquery.Selection) bool {
+			if match(option) {
+				value = option.AttrOr("value", "")
+				return false
+			}
+			return true
+		})
+
+		selected := options.Filter(`[selected]`)
+		if selected.Length() == 0 {
+			selected = options.First()
+		}
+
+		values[name] = value
+		changed = changed || value != selected.AttrOr("value", "")
+	}
+
+	if !changed {
+		return nil
+	}
+	return values
+}
+
 // GetSubmissions returns submissions metadata of given user.
 // If contest is not specified, returns all submissions of user.
 //
@@ -121,33 +362,164 @@ func (p *page) getSubmissions(arg Args) ([]Submission, error) {
 // rows of data. If pageCount is 1, the returned channel will keep returning page
 // data, till all verdicts of submissions in the page are declared.
 func (arg Args) GetSubmissions(handle string, pageCount uint) (<-chan []Submission, error) {
-	link, err := arg.SubmissionsPage(handle)
+	return DefaultClient.GetSubmissions(arg, handle, pageCount)
+}
+
+// GetSubmissionsContext is the same as GetSubmissions, using the given context.
+func (arg Args) GetSubmissionsContext(ctx context.Context, handle string, pageCount uint) (<-chan []Submission, error) {
+	return DefaultClient.GetSubmissionsContext(ctx, arg, handle, pageCount)
+}
+
+// GetSubmissionsWithOptions is the same as GetSubmissions, returning
+// only submissions passing the filters in opts. The filters are
+// applied through the status filter form of the website, where
+// possible, and on the parsed submissions otherwise.
+func (arg Args) GetSubmissionsWithOptions(handle string, pageCount uint, opts SubmissionsOptions) (<-chan []Submission, error) {
+	return DefaultClient.GetSubmissionsWithOptions(arg, handle, pageCount, opts)
+}
+
+// GetSubmissionsWithOptionsContext is the same as
+// GetSubmissionsWithOptions, using the given context.
+func (arg Args) GetSubmissionsWithOptionsContext(ctx context.Context, handle string, pageCount uint, opts SubmissionsOptions) (<-chan []Submission, error) {
+	return DefaultClient.GetSubmissionsWithOptionsContext(ctx, arg, handle, pageCount, opts)
+}
+
+// GetSubmissions returns submissions metadata of given user.
+// See Args.GetSubmissions for more details.
+func (c *Client) GetSubmissions(arg Args, handle string, pageCount uint) (<-chan []Submission, error) {
+	return c.GetSubmissionsContext(context.Background(), arg, handle, pageCount)
+}
+
+// GetSubmissionsContext is the same as GetSubmissions, using the given context.
+//
+// Once ctx is done, parsing (or polling of verdicts) is stopped,
+// the browser tab is closed and the returned channel is closed.
+func (c *Client) GetSubmissionsContext(ctx context.Context, arg Args, handle string, pageCount uint) (<-chan []Submission, error) {
+	return c.GetSubmissionsWithOptionsContext(ctx, arg, handle, pageCount, SubmissionsOptions{})
+}
+
+// GetSubmissionsWithOptions returns filtered submissions of given user.
+// See Args.GetSubmissionsWithOptions for more details.
+func (c *Client) GetSubmissionsWithOptions(arg Args, handle string, pageCount uint, opts SubmissionsOptions) (<-chan []Submission, error) {
+	return c.GetSubmissionsWithOptionsContext(context.Background(), arg, handle, pageCount, opts)
+}
+
+// GetSubmissionsWithOptionsContext is the same as
+// GetSubmissionsWithOptions, using the given context.
+func (c *Client) GetSubmissionsWithOptionsContext(ctx context.Context, arg Args, handle string, pageCount uint, opts SubmissionsOptions) (<-chan []Submission, error) {
+	// The logged in user is known only to the website.
+	if c != nil && c.api != nil && handle != "" {
+		return c.api.pageSubmissions(ctx, arg, handle, pageCount, opts)
+	}
+
+	link, err := c.submissionsPage(ctx, arg, handle)
 	if err != nil {
 		return nil, err
 	}
 
-	p, err := loadPage(link)
+	return c.submissions(ctx, arg, link, pageCount, opts)
+}
+
+// GetStatus returns submissions (of all users) in the status of
+// the contest, or problem in the contest. If contest is not
+// specified, returns submissions in the status of problemset.
+//
+// Parameters pageCount and opts are the same as of
+// GetSubmissionsWithOptions. If pageCount is 1, the returned
+// channel keeps returning the latest submissions, till all
+// verdicts (in the page) are declared.
+func (arg Args) GetStatus(pageCount uint, opts SubmissionsOptions) (<-chan []Submission, error) {
+	return DefaultClient.GetStatus(arg, pageCount, opts)
+}
+
+// GetStatusContext is the same as GetStatus, using the given context.
+func (arg Args) GetStatusContext(ctx context.Context, pageCount uint, opts SubmissionsOptions) (<-chan []Submission, error) {
+	return DefaultClient.GetStatusContext(ctx, arg, pageCount, opts)
+}
+
+// GetStatus returns submissions in the status of contest/problemset.
+// See Args.GetStatus for more details.
+func (c *Client) GetStatus(arg Args, pageCount uint, opts SubmissionsOptions) (<-chan []Submission, error) {
+	return c.GetStatusContext(context.Background(), arg, pageCount, opts)
+}
+
+// GetStatusContext is the same as GetStatus, using the given context.
+//
+// Once ctx is done, parsing (or polling of verdicts) is stopped,
+// the browser tab is closed and the returned channel is closed.
+func (c *Client) GetStatusContext(ctx context.Context, arg Args, pageCount uint, opts SubmissionsOptions) (<-chan []Submission, error) {
+	link, err := c.StatusPage(arg)
 	if err != nil {
 		return nil, err
 	}
 
-	if _, err := p.Race().Element(`#jGrowl .message`).Handle(handleErrMsg).
-		Element(`tr[data-submission-id]`).Do(); err != nil {
-		p.Close()
+	if c != nil && c.api != nil {
+		return c.api.pageSubmissions(ctx, arg, "", pageCount, opts)
+	}
+
+	return c.submissions(ctx, arg, link, pageCount, opts)
+}
+
+// submissions parses submissions in the page at link (in the
+// format of the submissions/status page), with filters applied.
+func (c *Client) submissions(ctx context.Context, arg Args, link string, pageCount uint, opts SubmissionsOptions) (<-chan []Submission, error) {
+	// Period of the contest, to tell practice submissions apart.
+	var start, end time.Time
+	if len(opts.Participants) != 0 && arg.Contest != "" {
+		var err error
+		if start, end, err = c.contestPeriod(ctx, arg); err != nil {
+			return nil, err
+		}
+	}
+
+	if c != nil && c.web != nil {
+		return c.web.pageSubmissions(ctx, arg, link, pageCount, opts, start, end)
+	}
+
+	p, err := c.loadPage(ctx, link)
+	if err != nil {
 		return nil, err
 	}
 
-	if p.MustInfo().URL != link {
+	// The table may have no rows (say, in a new contest).
+	if err := p.waitFor(link, `table.status-frame-datatable`); err != nil {
 		p.Close()
-		// An unexpected redirect occurred.
-		// Return error notification.
-		return nil, handleErrMsg(p.MustElement(`#jGrowl .message`))
+		return nil, err
 	}
 
 	// Wait till alls rows are loaded.
-	p.MustWaitLoad()
+	p.WaitLoad()
+
+	// Apply the filters on the website, where possible. Needed
+	// even without filters, as the website remembers the last
+	// filters set (in any other session).
+	if values := getStatusFilter(p.parse(), arg, opts); values != nil {
+		if err := rod.Try(func() {
+			form := p.MustElement(`form.status-filter`)
+			for name, value := range values {
+				if err := form.MustElement(fmt.Sprintf(`select[name="%v"]`, name)).
+					Select([]string{fmt.Sprintf(`[value="%v"]`, value)}, true, rod.SelectorTypeCSSSector); err != nil {
+					panic(err)
+				}
+			}
+			form.MustElement(`input[type="submit"]`).MustClick().WaitInvisible()
+			p.MustElement(`#footer`)
+			p.WaitLoad()
+		}); err != nil {
+			p.Close()
+			return nil, err
+		}
+	}
 
-	// @todo Add support for excluding unofficial submissions
+	// parse returns the filtered submissions in the page,
+	// and if older submissions (in later pages) are of use.
+	parse := func() ([]Submission, bool) {
+		// Ignore error, write whatever is parsed.
+		submissions, _ := getSubmissions(p.parse(), arg)
+		more := len(submissions) != 0 && (opts.Since.IsZero() ||
+			!submissions[len(submissions)-1].When.Before(opts.Since))
+		return opts.filter(submissions, arg, start, end), more
+	}
 
 	// create buffered channel for submissions
 	chanSubmissions := make(chan []Submission)
@@ -160,8 +532,12 @@ func (arg Args) GetSubmissions(handle string, pageCount uint) (<-chan []Submissi
 			for {
 				// Keep parsing verdict till
 				// all submission verdicts are finalised.
-				submissions, _ := p.getSubmissions(arg)
-				chanSubmissions <- submissions
+				submissions, _ := parse()
+				select {
+				case chanSubmissions <- submissions:
+				case <-ctx.Done():
+					return
+				}
 
 				IsJudging := false
 				for _, sub := range submissions {
@@ -172,18 +548,30 @@ func (arg Args) GetSubmissions(handle string, pageCount uint) (<-chan []Submissi
 				}
 				// Wait for atleast 1.5 seconds before parsing again.
 				timer := time.Now()
-				p.MustReload().WaitLoad()
-				time.Sleep(time.Millisecond*1500 - time.Since(timer))
+				if err := p.Reload(); err != nil {
+					break
+				}
+				p.WaitLoad()
+				if !p.sleep(time.Millisecond*1500 - time.Since(timer)) {
+					break
+				}
 			}
-		} else {
+			return
+		}
+
+		// Must methods panic once the context is done.
+		rod.Try(func() {
 			// Parse each page (without waiting for judgement to complete).
 			for ; pageCount > 0; pageCount-- {
-				// Ignore error, write whatever is parsed.
-				submissions, _ := p.getSubmissions(arg)
-				chanSubmissions <- submissions
+				submissions, more := parse()
+				select {
+				case chanSubmissions <- submissions:
+				case <-ctx.Done():
+					return
+				}
 
-				if !p.MustHasR(`.pagination li>a`, `→`) || pageCount == 1 {
-					// All pages parsed.
+				if !more || !p.MustHasR(`.pagination li>a`, `→`) || pageCount == 1 {
+					// All pages (of use) parsed.
 					break
 				}
 
@@ -191,36 +579,182 @@ func (arg Args) GetSubmissions(handle string, pageCount uint) (<-chan []Submissi
 				p.MustElementR(`.pagination li>a`, `→`).MustClick().WaitInvisible()
 				p.WaitLoad()
 			}
-		}
+		})
 	}()
 	return chanSubmissions, nil
 }
 
+// contestPeriod returns the start and end time of the contest.
+func (c *Client) contestPeriod(ctx context.Context, arg Args) (time.Time, time.Time, error) {
+	link, err := c.ContestsPage(arg)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	pd, err := c.fetch(ctx, link, `#footer`)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	contests, err := getContests(pd, arg)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if len(contests) == 0 {
+		return time.Time{}, time.Time{}, ErrContestNotFound
+	}
+	return contests[0].StartTime, contests[0].StartTime.Add(contests[0].Duration), nil
+}
+
+func getSubmissionDetails(pd *goquery.Document) (SubmissionDetails, error) {
+	var details SubmissionDetails
+
+	placeholder := pd.Find(`.tests-placeholder`)
+	if cmpErr := placeholder.Find(`.compilation-error pre`); cmpErr.Length() != 0 {
+		details.CompilationError = strings.TrimSpace(cmpErr.Text())
+		return details, nil
+	}
+
+	placeholder.Find(`.file-title`).Each(func(_ int, title *goquery.Selection) {
+		var result TestResult
+
+		// Format: 'Test: #1, time: 15 ms., memory: 0 KB, ..., verdict: OK'
+		for _, field := range strings.Split(clean(title.Text()), ",") {
+			kv := strings.SplitN(field, ":", 2)
+			if len(kv) != 2 {
+				continue
+			}
+
+			val := strings.TrimSpace(kv[1])
+			switch key := strings.ToLower(strings.TrimSpace(kv[0])); key {
+			case "test", "тест":
+				result.Test, _ = strconv.Atoi(strings.TrimPrefix(val, "#"))
+			case "time", "время":
+				result.Time = parseDuration(val)
+			case "memory", "память":
+				result.Memory = parseMemory(val)
+			case "verdict", "вердикт":
+				result.Verdict = val
+			}
+		}
+
+		// Files of the test follow the title, till the next test.
+		files := title.NextUntil(`.file-title`)
+		result.Input = files.Find(`.input-view pre`).Text()
+		result.Output = files.Find(`.output-view pre`).Text()
+		result.Answer = files.Find(`.answer-view pre`).Text()
+		result.CheckerLog = files.Find(`.checker-comment-view pre`).Text()
+
+		details.Protocol = append(details.Protocol, result)
+	})
+
+	return details, nil
+}
+
 // GetSourceCode returns submission code of given submission.
 func (sub Submission) GetSourceCode() (string, error) {
-	link, err := sub.SourceCodePage()
+	return DefaultClient.GetSourceCode(sub)
+}
+
+// GetSourceCodeContext is the same as GetSourceCode, using the given context.
+func (sub Submission) GetSourceCodeContext(ctx context.Context) (string, error) {
+	return DefaultClient.GetSourceCodeContext(ctx, sub)
+}
+
+// GetSourceCode returns submission code of given submission.
+func (c *Client) GetSourceCode(sub Submission) (string, error) {
+	return c.GetSourceCodeContext(context.Background(), sub)
+}
+
+// GetSourceCodeContext is the same as GetSourceCode, using the given context.
+//
+// If the source is SourceHTTP, the code is returned as served,
+// without the filtering the website applies when copying it.
+func (c *Client) GetSourceCodeContext(ctx context.Context, sub Submission) (string, error) {
+	link, err := c.SourceCodePage(sub)
 	if err != nil {
 		return "", err
 	}
 
-	p, err := loadPage(link)
+	if c != nil && c.web != nil {
+		// Filtering (done by script) is skipped.
+		pd, err := c.web.get(ctx, link)
+		if err != nil {
+			return "", err
+		}
+		return pd.Find(`#program-source-text`).Text(), nil
+	}
+
+	p, err := c.loadPage(ctx, link)
 	if err != nil {
 		return "", err
 	}
 	defer p.Close()
 
-	if _, err := p.Race().Element(`#jGrowl .message`).Handle(handleErrMsg).
-		Element(`#program-source-text`).Do(); err != nil {
+	if err := p.waitFor(link, `#program-source-text`); err != nil {
+		return "", err
+	}
+
+	res, err := p.Eval(`Codeforces.filterClipboardText(
+		document.querySelector("#program-source-text").innerText)`)
+	if err != nil {
 		return "", err
 	}
+	return res.Value.String(), nil
+}
+
+// GetDetails returns the judge protocol of the submission, along
+// with the compilation error message (if any). Details are shown
+// by the website only for own submissions (or once the contest
+// is over); ErrAccessDenied is returned otherwise.
+func (sub Submission) GetDetails() (SubmissionDetails, error) {
+	return DefaultClient.GetDetails(sub)
+}
+
+// GetDetailsContext is the same as GetDetails, using the given context.
+func (sub Submission) GetDetailsContext(ctx context.Context) (SubmissionDetails, error) {
+	return DefaultClient.GetDetailsContext(ctx, sub)
+}
+
+// GetDetails returns the judge protocol of given submission.
+// See Submission.GetDetails for more details.
+func (c *Client) GetDetails(sub Submission) (SubmissionDetails, error) {
+	return c.GetDetailsContext(context.Background(), sub)
+}
+
+// GetDetailsContext is the same as GetDetails, using the given context.
+func (c *Client) GetDetailsContext(ctx context.Context, sub Submission) (SubmissionDetails, error) {
+	link, err := c.SourceCodePage(sub)
+	if err != nil {
+		return SubmissionDetails{}, err
+	}
+
+	p, err := c.loadPage(ctx, link)
+	if err != nil {
+		return SubmissionDetails{}, err
+	}
+	defer p.Close()
+
+	if err := p.waitFor(link, `#program-source-text`); err != nil {
+		return SubmissionDetails{}, err
+	}
+
+	has, elm, err := p.Has(`.click-to-view-tests`)
+	if err != nil {
+		return SubmissionDetails{}, err
+	}
+	if !has {
+		return SubmissionDetails{}, ErrAccessDenied
+	}
 
-	if p.MustInfo().URL != link {
-		// An unexpected redirect occurred.
-		// Return error notification.
-		return "", handleErrMsg(p.MustElement(`#jGrowl .message`))
+	// Test details are loaded only once requested.
+	if err := rod.Try(func() {
+		elm.MustClick()
+		p.Race().Element(`.tests-placeholder .file-title`).
+			Element(`.tests-placeholder .compilation-error`).MustDo()
+	}); err != nil {
+		return SubmissionDetails{}, err
 	}
 
-	sourceCode := p.MustEval(`Codeforces.filterClipboardText(
-		document.querySelector("#program-source-text").innerText)`).String()
-	return sourceCode, nil
+	return getSubmissionDetails(p.parse())
 }
@@ -1,12 +1,14 @@
 package codeforces
 
 import (
+	"context"
 	"reflect"
 	"testing"
 	"time"
 )
 
 func TestArgs_GetSubmissions(t *testing.T) {
+	skipOffline(t)
 	time.Sleep(time.Second * 10)
 
 	type args struct {
@@ -36,6 +38,8 @@ func TestArgs_GetSubmissions(t *testing.T) {
 					VerdictStatus: VerdictCE,
 					Time:          "0 ms",
 					Memory:        "0 KB",
+					TimeDuration:  0,
+					MemoryBytes:   0,
 					IsJudging:     false,
 					Arg:           Args{"4", "a", "contest", ""},
 				},
@@ -49,6 +53,8 @@ func TestArgs_GetSubmissions(t *testing.T) {
 					VerdictStatus: VerdictCE,
 					Time:          "0 ms",
 					Memory:        "0 KB",
+					TimeDuration:  0,
+					MemoryBytes:   0,
 					IsJudging:     false,
 					Arg:           Args{"4", "a", "contest", ""},
 				},
@@ -62,6 +68,8 @@ func TestArgs_GetSubmissions(t *testing.T) {
 					VerdictStatus: VerdictRTE,
 					Time:          "46 ms",
 					Memory:        "0 KB",
+					TimeDuration:  time.Millisecond * 46,
+					MemoryBytes:   0,
 					IsJudging:     false,
 					Arg:           Args{"4", "b", "contest", ""},
 				},
@@ -75,6 +83,8 @@ func TestArgs_GetSubmissions(t *testing.T) {
 					VerdictStatus: VerdictAC,
 					Time:          "62 ms",
 					Memory:        "0 KB",
+					TimeDuration:  time.Millisecond * 62,
+					MemoryBytes:   0,
 					IsJudging:     false,
 					Arg:           Args{"4", "a", "contest", ""},
 				},
@@ -96,6 +106,8 @@ func TestArgs_GetSubmissions(t *testing.T) {
 					VerdictStatus: VerdictRTE,
 					Time:          "46 ms",
 					Memory:        "0 KB",
+					TimeDuration:  time.Millisecond * 46,
+					MemoryBytes:   0,
 					IsJudging:     false,
 					Arg:           Args{"4", "b", "contest", ""},
 				},
@@ -145,7 +157,6 @@ func TestArgs_GetSubmissions(t *testing.T) {
 				t.Log("Data rows in page:", len(v))
 				submissions = append(submissions, v...)
 			}
-
 			if tt.shouldSkip {
 				// Check for duplicates.
 				tmpMap := make(map[Submission]bool)
@@ -170,7 +181,116 @@ func TestArgs_GetSubmissions(t *testing.T) {
 	}
 }
 
+func TestArgs_GetSubmissionsContext(t *testing.T) {
+	skipOffline(t)
+	time.Sleep(time.Second * 10)
+
+	arg := Args{"4", "", "contest", ""}
+
+	// Expired context; no page should be loaded.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := arg.GetSubmissionsContext(ctx, "cp-tools", 1); err != context.Canceled {
+		t.Errorf("Args.GetSubmissionsContext() error = %v, want %v", err, context.Canceled)
+	}
+
+	// Consumer walks away after the first page.
+	ctx, cancel = context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	got, err := arg.GetSubmissionsContext(ctx, "cp-tools", 1e9)
+	if err != nil {
+		t.Fatalf("Args.GetSubmissionsContext() error = %v", err)
+	}
+	<-got
+	cancel()
+
+	// Channel must be closed shortly after cancellation.
+	timer := time.After(time.Second * 10)
+	for {
+		select {
+		case _, ok := <-got:
+			if !ok {
+				return
+			}
+		case <-timer:
+			t.Fatalf("Args.GetSubmissionsContext() channel not closed after cancellation")
+		}
+	}
+}
+
+func TestSubmissionsOptions_filter(t *testing.T) {
+	at := func(h, m int) time.Time {
+		return time.Date(2020, time.May, 24, h, m, 0, 0, time.UTC)
+	}
+
+	arg := Args{"4", "", "contest", ""}
+	submissions := []Submission{
+		{ID: "1", When: at(16, 0), Language: "GNU C++17", VerdictStatus: VerdictAC, Arg: Args{"4", "a", "contest", ""}},
+		{ID: "2", When: at(17, 30), Language: "Python 3", VerdictStatus: VerdictWA, Arg: Args{"4", "b", "contest", ""}},
+		{ID: "3", When: at(18, 0), Language: "GNU C++17", IsJudging: true, Arg: Args{"4", "b", "contest", ""}},
+		{ID: "4", When: at(19, 0), Language: "GNU C++17", VerdictStatus: VerdictWA, Participant: ParticipantVirtual, Arg: Args{"4", "c", "contest", ""}},
+	}
+
+	ids := func(submissions []Submission) (ids []string) {
+		for _, sub := range submissions {
+			ids = append(ids, sub.ID)
+		}
+		return
+	}
+
+	tests := []struct {
+		name       string
+		opts       SubmissionsOptions
+		start, end time.Time
+		want       []string
+	}{
+		{
+			name: "Test #1",
+			opts: SubmissionsOptions{},
+			want: []string{"1", "2", "3", "4"},
+		},
+		{
+			name: "Test #2", // Judging submissions are included.
+			opts: SubmissionsOptions{Verdicts: []int{VerdictWA}},
+			want: []string{"2", "3", "4"},
+		},
+		{
+			name: "Test #3",
+			opts: SubmissionsOptions{Family: "C++", Problem: "B"},
+			want: []string{"3"},
+		},
+		{
+			name: "Test #4",
+			opts: SubmissionsOptions{Since: at(17, 30), Until: at(19, 0)},
+			want: []string{"2", "3"},
+		},
+		{
+			name:  "Test #5",
+			opts:  SubmissionsOptions{Participants: []int{ParticipantPractice, ParticipantVirtual}},
+			start: at(17, 0),
+			end:   at(18, 0),
+			want:  []string{"1", "3", "4"},
+		},
+		{
+			name:  "Test #6",
+			opts:  SubmissionsOptions{Participants: []int{ParticipantContestant}},
+			start: at(17, 0),
+			end:   at(18, 0),
+			want:  []string{"2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ids(tt.opts.filter(submissions, arg, tt.start, tt.end))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SubmissionsOptions.filter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestSubmission_GetSourceCode(t *testing.T) {
+	skipOffline(t)
 	time.Sleep(time.Second * 10)
 
 	tests := []struct {
@@ -251,3 +371,41 @@ int main() {
 		})
 	}
 }
+
+func TestSubmission_GetDetails(t *testing.T) {
+	skipOffline(t)
+	time.Sleep(time.Second * 10)
+
+	sub := Submission{ID: "81012854", Arg: Args{"4", "", "contest", ""}} // just bare info here
+	got, err := sub.GetDetails()
+	if err != nil {
+		t.Fatalf("Submission.GetDetails() error = %v", err)
+	}
+	if len(got.Protocol) == 0 || got.Protocol[0].Test != 1 || got.Protocol[0].Verdict == "" {
+		t.Errorf("Submission.GetDetails() = %v", got)
+	}
+}
+
+func TestArgs_GetStatus(t *testing.T) {
+	skipOffline(t)
+	time.Sleep(time.Second * 10)
+
+	arg := Args{"4", "a", "contest", ""}
+	chanSubmissions, err := arg.GetStatus(2, SubmissionsOptions{Verdicts: []int{VerdictAC}})
+	if err != nil {
+		t.Fatalf("Args.GetStatus() error = %v", err)
+	}
+
+	count := 0
+	for submissions := range chanSubmissions {
+		for _, sub := range submissions {
+			if sub.Arg.Problem != "a" || (sub.VerdictStatus != VerdictAC && !sub.IsJudging) {
+				t.Errorf("Args.GetStatus() returned %v", sub)
+			}
+		}
+		count += len(submissions)
+	}
+	if count == 0 {
+		t.Errorf("Args.GetStatus() returned no submissions")
+	}
+}
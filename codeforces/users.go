@@ -0,0 +1,177 @@
+package codeforces
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// User holds details from the profile of a user. Ranks are in
+// lower case ('legendary grandmaster'), and are empty if the
+// user is unrated. Location and organization are empty if not
+// set by the user.
+type User struct {
+	Handle       string
+	Rating       int
+	MaxRating    int
+	Rank         string
+	MaxRank      string
+	Contribution int
+	Friends      int
+	Organization string
+	Country      string
+	City         string
+	Registered   time.Time
+	// LastVisit is the time the user was last online; about
+	// the time of the request, if the user is online now.
+	LastVisit time.Time
+	Avatar    string
+}
+
+// Keys (in lower case) of each detail in the profile,
+// in all supported interface languages.
+var profileKeys = map[string][]string{
+	"rating":       {"contest rating", "рейтинг"},
+	"contribution": {"contribution", "вклад"},
+	"friends":      {"friend of", "в друзьях у"},
+	"lastVisit":    {"last visit", "был"},
+	"registered":   {"registered", "зарегистрирован"},
+}
+
+// profileKey returns the key (in profileKeys) of the detail
+// in the given line of the profile, if any.
+func profileKey(line string) string {
+	pos := strings.Index(line, ":")
+	if pos == -1 {
+		return ""
+	}
+
+	lkey := strings.ToLower(strings.TrimSpace(line[:pos]))
+	for key, vals := range profileKeys {
+		for _, val := range vals {
+			if strings.HasPrefix(lkey, val) {
+				return key
+			}
+		}
+	}
+	return ""
+}
+
+// absURL returns the protocol-relative link as a https link.
+func absURL(link string) string {
+	if strings.HasPrefix(link, "//") {
+		return "https:" + link
+	}
+	return link
+}
+
+func getUser(pd *goquery.Document) (User, error) {
+	var user User
+
+	info := pd.Find(`.userbox .info`)
+	if info.Length() == 0 {
+		return User{}, ErrUserNotFound
+	}
+
+	user.Handle = clean(info.Find(`.main-info h1 a`).Text())
+	user.Rank = strings.ToLower(clean(info.Find(`.main-info .user-rank`).Text()))
+	user.Avatar = absURL(pd.Find(`.userbox .title-photo img`).AttrOr("src", ""))
+
+	// Location and organization link to their ratings.
+	user.City = clean(info.Find(`a[href*="/ratings/city/"]`).Text())
+	user.Country = clean(info.Find(`a[href*="/ratings/country/"]`).Text())
+	user.Organization = clean(info.Find(`a[href*="/ratings/organization/"]`).Text())
+
+	digits := regexp.MustCompile(`[+-]?\d+`)
+	info.Find(`li`).Each(func(_ int, line *goquery.Selection) {
+		switch profileKey(clean(line.Text())) {
+		case "rating":
+			user.Rating, _ = strconv.Atoi(clean(line.ChildrenFiltered(`span`).First().Text()))
+			// Format: '(max. legendary grandmaster, 3979)'
+			if maxSel := line.Find(`.smaller span`); maxSel.Length() >= 2 {
+				user.MaxRank = strings.ToLower(clean(maxSel.First().Text()))
+				user.MaxRating, _ = strconv.Atoi(clean(maxSel.Last().Text()))
+			}
+
+		case "contribution":
+			user.Contribution, _ = strconv.Atoi(digits.FindString(line.Find(`span`).Text()))
+
+		case "friends":
+			user.Friends, _ = strconv.Atoi(digits.FindString(line.Text()))
+
+		case "lastVisit":
+			if when, ok := line.Find(`.format-humantime`).Attr("title"); ok {
+				user.LastVisit = parseTime(when)
+			} else {
+				// The user is online.
+				user.LastVisit = time.Now().UTC().Truncate(time.Minute)
+			}
+
+		case "registered":
+			user.Registered = parseTime(line.Find(`.format-humantime`).AttrOr("title", ""))
+		}
+	})
+
+	if user.Rating == 0 {
+		// Rank is shown as 'unrated' (in the interface language).
+		user.Rank = ""
+	}
+	return user, nil
+}
+
+// GetUser returns details from the profile of the user with the
+// given handle. If the handle is empty, returns details of the
+// logged in user (ErrNotLoggedIn is returned if none). Returns
+// ErrUserNotFound if no user has the handle.
+// Uses DefaultClient.
+func GetUser(handle string) (User, error) {
+	return DefaultClient.GetUser(handle)
+}
+
+// GetUserContext is the same as GetUser, using the given context.
+func GetUserContext(ctx context.Context, handle string) (User, error) {
+	return DefaultClient.GetUserContext(ctx, handle)
+}
+
+// GetUser returns details from the profile of the user.
+// See GetUser for more details.
+func (c *Client) GetUser(handle string) (User, error) {
+	return c.GetUserContext(context.Background(), handle)
+}
+
+// GetUserContext is the same as GetUser, using the given context.
+func (c *Client) GetUserContext(ctx context.Context, handle string) (User, error) {
+	if handle == "" {
+		var err error
+		if handle, err = c.CurrentUserContext(ctx); err != nil {
+			return User{}, err
+		}
+		if handle == "" {
+			return User{}, ErrNotLoggedIn
+		}
+	}
+
+	if c != nil && c.api != nil {
+		return c.api.user(ctx, handle)
+	}
+
+	link, err := c.ProfilePage(handle)
+	if err != nil {
+		return User{}, err
+	}
+
+	pd, err := c.fetch(ctx, link, `#footer`)
+	if errors.Is(err, ErrRedirected) {
+		// Redirected to the home page.
+		return User{}, ErrUserNotFound
+	}
+	if err != nil {
+		return User{}, err
+	}
+	return getUser(pd)
+}
@@ -0,0 +1,27 @@
+package codeforces
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGetUser(t *testing.T) {
+	skipOffline(t)
+	time.Sleep(time.Second * 10)
+
+	got, err := GetUser("tourist")
+	if err != nil {
+		t.Fatalf("GetUser() error = %v", err)
+	}
+
+	// Rating and such keep changing; check only what doesn't.
+	if got.Handle != "tourist" || got.Country != "Belarus" || got.Rating == 0 ||
+		got.MaxRating < got.Rating || got.Registered.Year() != 2010 {
+		t.Errorf("GetUser() = %v", got)
+	}
+
+	if _, err := GetUser("invalid handle"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("GetUser() error = %v, want %v", err, ErrUserNotFound)
+	}
+}
@@ -1,7 +1,11 @@
 package codeforces
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
@@ -14,7 +18,16 @@ import (
 	"github.com/go-rod/rod/lib/proto"
 )
 
-func loadPage(link string) (*page, error) {
+func (c *Client) loadPage(ctx context.Context, link string) (*page, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	bs, err := c.browser()
+	if err != nil {
+		return nil, err
+	}
+
 	// Blocking these files results in faster page loading.
 	resourcesToBlock := []proto.NetworkResourceType{
 		proto.NetworkResourceTypeFont,
@@ -23,21 +36,208 @@ func loadPage(link string) (*page, error) {
 		proto.NetworkResourceTypeStylesheet,
 	}
 
-	p, err := util.NewPage(Browser, link, resourcesToBlock)
-	return &page{p}, err
+	p, err := util.NewPageWithCassette(bs, link, resourcesToBlock, c.cassette)
+	if err != nil {
+		return nil, err
+	}
+
+	// All operations on the page abort once ctx is done.
+	return &page{Page: p.Context(ctx), origin: p, host: c.Host()}, nil
+}
+
+// fetch loads the page at link, and returns it parsed, once an
+// element matching selector is loaded. If the source is SourceHTTP,
+// the page is fetched over plain HTTP instead, and returned as is.
+func (c *Client) fetch(ctx context.Context, link, selector string) (*goquery.Document, error) {
+	if c != nil && c.web != nil {
+		return c.web.get(ctx, link)
+	}
+
+	p, err := c.loadPage(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+	defer p.Close()
+
+	if err := p.waitFor(link, selector); err != nil {
+		return nil, err
+	}
+	return p.parse(), nil
+}
+
+// Close closes the browser tab of the page. This works
+// even if the context bound to the page has expired.
+func (p *page) Close() error {
+	return p.origin.Close()
+}
+
+// sleep pauses for the given duration. Returns
+// false if the page context is done before that.
+func (p *page) sleep(d time.Duration) bool {
+	select {
+	case <-time.After(d):
+		return true
+	case <-p.GetContext().Done():
+		return false
+	}
+}
+
+// waitFor waits till an element matching selector is loaded
+// in the page. If an error notification is shown instead, or
+// if the page was redirected, the notification is returned.
+func (p *page) waitFor(link, selector string) error {
+	if _, err := p.Race().Element(`#jGrowl .message`).Handle(handleErrMsg).
+		Element(selector).Do(); err != nil {
+		return err
+	}
+
+	info, err := p.Info()
+	if err != nil {
+		return err
+	}
+
+	if info.URL != link {
+		// An unexpected redirect occurred.
+		// Return error notification, if any.
+		has, e, err := p.Has(`#jGrowl .message`)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return ErrRedirected
+		}
+		return handleErrMsg(e)
+	}
+
+	return nil
+}
+
+// httpClient returns a client with the cookies (logged in
+// session) of the page, to fetch files not loaded by the
+// page (like images).
+func (p *page) httpClient() *http.Client {
+	jar, _ := cookiejar.New(nil)
+	if u, err := url.Parse(p.host); err == nil {
+		jar.SetCookies(u, p.cookies())
+	}
+	return &http.Client{Jar: jar}
+}
+
+// cookies returns the cookies of the website in the browser.
+func (p *page) cookies() []*http.Cookie {
+	var cookies []*http.Cookie
+	if res, err := (proto.NetworkGetCookies{Urls: []string{p.host}}).Call(p); err == nil {
+		for _, cookie := range res.Cookies {
+			cookies = append(cookies, &http.Cookie{Name: cookie.Name, Value: cookie.Value})
+		}
+	}
+	return cookies
+}
+
+// mustSelectValue selects the option with the given value in the
+// select element. Unlike selecting by text (which matches any
+// option containing the text), only the exact option is selected.
+func mustSelectValue(sel *rod.Element, value string) {
+	selector := fmt.Sprintf(`option[value="%v"]`, value)
+	if err := sel.Select([]string{selector}, true, rod.SelectorTypeCSSSector); err != nil {
+		panic(err)
+	}
 }
 
 func handleErrMsg(e *rod.Element) error {
 	// There should be no notification.
-	return fmt.Errorf(e.MustText())
+	msg, err := e.Text()
+	if err != nil {
+		return err
+	}
+	return newSiteError(clean(msg))
+}
+
+// Notification messages (in lower case) of each error, in
+// all supported interface languages (english and russian).
+var siteErrMsgs = []struct {
+	err  error
+	msgs []string
+}{
+	{ErrContestNotFound, []string{"no such contest", "нет такого соревнования"}},
+	{ErrAccessDenied, []string{"you are not allowed", "вы не можете", "access denied", "доступ запрещен"}},
+	{ErrNotLoggedIn, []string{"please, login", "you should be logged in", "пожалуйста, войдите", "войдите в систему"}},
+	{ErrDuplicateSubmission, []string{"exactly the same code", "абсолютно такой же код"}},
+	{ErrSubmissionClosed, []string{"contest is over", "submit is disabled", "соревнование закончилось", "отправка запрещена"}},
+	{ErrLanguageNotAllowed, []string{"language is not allowed", "язык не разрешен"}},
+	{ErrRegistrationClosed, []string{"registration is closed", "регистрация закрыта"}},
+	{ErrInvalidCredentials, []string{"invalid handle/email or password", "неверный хэндл/email или пароль"}},
+}
+
+// newSiteError returns the library error, corresponding to the
+// notification message shown, wrapped with the message. Messages
+// not recognised correspond to ErrRedirected, since notifications
+// are shown only on a redirect from the requested page.
+func newSiteError(msg string) error {
+	lmsg := strings.ToLower(msg)
+	for _, v := range siteErrMsgs {
+		for _, str := range v.msgs {
+			if strings.Contains(lmsg, str) {
+				return &SiteError{Err: v.err, Msg: msg}
+			}
+		}
+	}
+	return &SiteError{Err: ErrRedirected, Msg: msg}
+}
+
+// renderText replaces the contents of all elements matching
+// selector by their text, as rendered by the browser.
+func (p *page) renderText(selector string) error {
+	_, err := p.Eval(`(sel) => document.querySelectorAll(sel).forEach(
+		(e) => e.textContent = e.innerText)`, selector)
+	return err
 }
 
 func (p *page) parse() *goquery.Document {
-	pd, _ := goquery.NewDocumentFromReader(strings.NewReader(
-		p.MustElement(`html`).MustHTML()))
+	html, _ := p.HTML()
+	pd, _ := goquery.NewDocumentFromReader(strings.NewReader(html))
 	return pd
 }
 
+// innerText returns the text of the given preformatted element,
+// as a browser would render it, for pages not loaded in the
+// browser. Line breaks and line wrapping divs are replaced by '\n'.
+func innerText(sel *goquery.Selection) string {
+	var sb strings.Builder
+	sel.Contents().Each(func(_ int, node *goquery.Selection) {
+		switch goquery.NodeName(node) {
+		case "#text":
+			sb.WriteString(node.Text())
+		case "br":
+			sb.WriteString("\n")
+		case "div":
+			sb.WriteString(innerText(node))
+			if str := sb.String(); !strings.HasSuffix(str, "\n") {
+				sb.WriteString("\n")
+			}
+		default:
+			sb.WriteString(innerText(node))
+		}
+	})
+	return sb.String()
+}
+
+// getErrMsg returns the error notification (if any) in the
+// page. Notifications are shown on page load, through script.
+func getErrMsg(pd *goquery.Document) error {
+	re := regexp.MustCompile(`Codeforces\.showMessage\(\s*(?:"((?:[^"\\]|\\.)*)"|'((?:[^'\\]|\\.)*)')`)
+	match := re.FindStringSubmatch(pd.Find(`script`).Text())
+	if match == nil {
+		return nil
+	}
+
+	msg := strings.ReplaceAll(match[1]+match[2], `\'`, `'`)
+	if str, err := strconv.Unquote(`"` + msg + `"`); err == nil {
+		msg = str
+	}
+	return newSiteError(clean(msg))
+}
+
 func clean(str string) string {
 	// remove trailiing/leading spaces
 	str = strings.ReplaceAll(str, "<br/>", "\n")
@@ -107,3 +307,68 @@ func parseTime(link string) time.Time {
 	}
 	return tm.UTC()
 }
+
+// parseDuration parses time strings, in the formats used by the
+// website ('2 seconds', '0.5 s', '46 ms', '1 секунда', '15 мс'...).
+// Amounts without any unit are considered to be in seconds.
+// If the string is invalid, returns 0.
+func parseDuration(str string) time.Duration {
+	re := regexp.MustCompile(`(\d+(?:[.,]\d+)?)\s*(\p{L}*)`)
+	pst := re.FindStringSubmatch(clean(str))
+	if pst == nil {
+		return 0
+	}
+
+	val, err := strconv.ParseFloat(strings.ReplaceAll(pst[1], ",", "."), 64)
+	if err != nil {
+		return 0
+	}
+
+	unit := time.Second
+	switch lunit := strings.ToLower(pst[2]); {
+	case strings.HasPrefix(lunit, "ms"), strings.HasPrefix(lunit, "мс"),
+		strings.HasPrefix(lunit, "milli"), strings.HasPrefix(lunit, "милли"):
+		unit = time.Millisecond
+	case strings.HasPrefix(lunit, "min"), strings.HasPrefix(lunit, "мин"):
+		unit = time.Minute
+	}
+	return time.Duration(val * float64(unit))
+}
+
+// parseMemory parses memory strings, in the formats used by the
+// website ('256 megabytes', '64 MB', '0 KB', '256 мегабайт'...),
+// and returns the number of bytes. Amounts without any unit are
+// considered to be in bytes. If the string is invalid, returns 0.
+func parseMemory(str string) int64 {
+	re := regexp.MustCompile(`(\d+(?:[.,]\d+)?)\s*(\p{L}*)`)
+	pst := re.FindStringSubmatch(clean(str))
+	if pst == nil {
+		return 0
+	}
+
+	val, err := strconv.ParseFloat(strings.ReplaceAll(pst[1], ",", "."), 64)
+	if err != nil {
+		return 0
+	}
+
+	var unit int64 = 1
+	switch lunit := strings.ToLower(pst[2]); {
+	case strings.HasPrefix(lunit, "k"), strings.HasPrefix(lunit, "к"):
+		unit = 1 << 10
+	case strings.HasPrefix(lunit, "m"), strings.HasPrefix(lunit, "м"):
+		unit = 1 << 20
+	case strings.HasPrefix(lunit, "g"), strings.HasPrefix(lunit, "г"):
+		unit = 1 << 30
+	}
+	return int64(val * float64(unit))
+}
+
+// containsInt reports whether val is present in arr.
+func containsInt(arr []int, val int) bool {
+	for _, v := range arr {
+		if v == val {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,7 @@
 package codeforces
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 	"time"
@@ -54,3 +55,172 @@ func Test_parseTime(t *testing.T) {
 		})
 	}
 }
+
+func Test_newSiteError(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  string
+		want error
+	}{
+		{
+			name: "Test #1",
+			msg:  "No such contest",
+			want: ErrContestNotFound,
+		},
+		{
+			name: "Test #2", // Russian locale.
+			msg:  "Нет такого соревнования",
+			want: ErrContestNotFound,
+		},
+		{
+			name: "Test #3",
+			msg:  "You are not allowed to view the contest",
+			want: ErrAccessDenied,
+		},
+		{
+			name: "Test #4", // Russian locale.
+			msg:  "Вы не можете просматривать данное соревнование",
+			want: ErrAccessDenied,
+		},
+		{
+			name: "Test #5",
+			msg:  "You have submitted exactly the same code before",
+			want: ErrDuplicateSubmission,
+		},
+		{
+			name: "Test #6", // Russian locale.
+			msg:  "Вы уже отправляли абсолютно такой же код",
+			want: ErrDuplicateSubmission,
+		},
+		{
+			name: "Test #7",
+			msg:  "Invalid handle/email or password",
+			want: ErrInvalidCredentials,
+		},
+		{
+			name: "Test #8", // Unknown message.
+			msg:  "Something went wrong",
+			want: ErrRedirected,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newSiteError(tt.msg)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("newSiteError() error = %v, want %v", err, tt.want)
+			}
+
+			var siteErr *SiteError
+			if !errors.As(err, &siteErr) || siteErr.Msg != tt.msg {
+				t.Errorf("newSiteError() message = %v, want %v", err, tt.msg)
+			}
+		})
+	}
+}
+
+func Test_parseDuration(t *testing.T) {
+	tests := []struct {
+		name string
+		str  string
+		want time.Duration
+	}{
+		{
+			name: "Test #1",
+			str:  "2 seconds",
+			want: time.Second * 2,
+		},
+		{
+			name: "Test #2",
+			str:  "0.5 second",
+			want: time.Millisecond * 500,
+		},
+		{
+			name: "Test #3",
+			str:  "1.5 s",
+			want: time.Millisecond * 1500,
+		},
+		{
+			name: "Test #4",
+			str:  "46 ms",
+			want: time.Millisecond * 46,
+		},
+		{
+			name: "Test #5", // Russian locale.
+			str:  "2 секунды",
+			want: time.Second * 2,
+		},
+		{
+			name: "Test #6", // Russian locale.
+			str:  "15 мс",
+			want: time.Millisecond * 15,
+		},
+		{
+			name: "Test #7", // Russian locale.
+			str:  "0,25 секунды",
+			want: time.Millisecond * 250,
+		},
+		{
+			name: "Test #8",
+			str:  "invalid",
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseDuration(tt.str); got != tt.want {
+				t.Errorf("parseDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseMemory(t *testing.T) {
+	tests := []struct {
+		name string
+		str  string
+		want int64
+	}{
+		{
+			name: "Test #1",
+			str:  "256 megabytes",
+			want: 256 << 20,
+		},
+		{
+			name: "Test #2",
+			str:  "64 MB",
+			want: 64 << 20,
+		},
+		{
+			name: "Test #3",
+			str:  "3600 KB",
+			want: 3600 << 10,
+		},
+		{
+			name: "Test #4", // Russian locale.
+			str:  "256 мегабайт",
+			want: 256 << 20,
+		},
+		{
+			name: "Test #5", // Russian locale.
+			str:  "100 КБ",
+			want: 100 << 10,
+		},
+		{
+			name: "Test #6",
+			str:  "1 gigabyte",
+			want: 1 << 30,
+		},
+		{
+			name: "Test #7",
+			str:  "invalid",
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseMemory(tt.str); got != tt.want {
+				t.Errorf("parseMemory() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
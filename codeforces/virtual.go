@@ -0,0 +1,158 @@
+package codeforces
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/go-rod/rod"
+)
+
+// VirtualState holds the state of virtual participation.
+type VirtualState struct {
+	Status int
+	// Remaining is the time before the virtual contest begins
+	// (if pending), or the time before it ends (if running).
+	Remaining time.Duration
+}
+
+// Virtual participation status.
+const (
+	VirtualNone = iota
+	VirtualPending
+	VirtualRunning
+)
+
+func getVirtualState(pd *goquery.Document) (VirtualState, error) {
+	var state VirtualState
+
+	sidebox := strings.ToLower(clean(pd.Find(`#sidebar .roundbox`).First().Text()))
+	if !strings.Contains(sidebox, "virtual") && !strings.Contains(sidebox, "виртуальн") {
+		return state, nil
+	}
+
+	phase := strings.ToLower(clean(pd.Find(`.contest-state-phase`).Text()))
+	switch {
+	case strings.Contains(phase, "before"), strings.Contains(phase, "до начала"):
+		state.Status = VirtualPending
+	case phase == "finished", phase == "закончено":
+		return state, nil
+	default:
+		state.Status = VirtualRunning
+	}
+
+	remaining, err := getCountdown(pd)
+	state.Remaining = remaining
+	return state, err
+}
+
+// StartVirtual registers for virtual participation in the
+// contest/gym, starting at startTime (in the time zone set in
+// the user settings), and returns the virtual participation
+// state. The contest is started right away if startTime is zero.
+// Set team to the name of the team to take part as, or leave
+// empty to take part as an individual.
+//
+// ErrVirtualNotAllowed is returned if virtual participation
+// isn't allowed (say, the contest is running).
+func (arg Args) StartVirtual(startTime time.Time, team string) (VirtualState, error) {
+	return DefaultClient.StartVirtual(arg, startTime, team)
+}
+
+// StartVirtualContext is the same as StartVirtual, using the given context.
+func (arg Args) StartVirtualContext(ctx context.Context, startTime time.Time, team string) (VirtualState, error) {
+	return DefaultClient.StartVirtualContext(ctx, arg, startTime, team)
+}
+
+// GetVirtualState returns the state of virtual participation
+// in the contest/gym, along with the remaining time.
+func (arg Args) GetVirtualState() (VirtualState, error) {
+	return DefaultClient.GetVirtualState(arg)
+}
+
+// GetVirtualStateContext is the same as GetVirtualState, using the given context.
+func (arg Args) GetVirtualStateContext(ctx context.Context) (VirtualState, error) {
+	return DefaultClient.GetVirtualStateContext(ctx, arg)
+}
+
+// StartVirtual registers for virtual participation in the contest.
+// See Args.StartVirtual for more details.
+func (c *Client) StartVirtual(arg Args, startTime time.Time, team string) (VirtualState, error) {
+	return c.StartVirtualContext(context.Background(), arg, startTime, team)
+}
+
+// StartVirtualContext is the same as StartVirtual, using the given context.
+func (c *Client) StartVirtualContext(ctx context.Context, arg Args, startTime time.Time, team string) (VirtualState, error) {
+	link, err := c.VirtualPage(arg)
+	if err != nil {
+		return VirtualState{}, err
+	}
+
+	p, err := c.loadPage(ctx, link)
+	if err != nil {
+		return VirtualState{}, err
+	}
+	defer p.Close()
+
+	if err := p.waitFor(link, `#footer`); err != nil {
+		return VirtualState{}, err
+	}
+
+	pd := p.parse()
+	if err := checkRegister(pd, RegisterOptions{Team: team}); err != nil {
+		if err == ErrRegistrationClosed {
+			err = ErrVirtualNotAllowed
+		}
+		return VirtualState{}, err
+	}
+
+	// All cases have been handled. Complete the form.
+	if err := rod.Try(func() {
+		form := p.MustElement(`form.contestRegistrationForm`)
+		if team != "" {
+			// Select the team by value; names may be substrings of others.
+			mustSelectValue(form.MustElement(`select[name="takePartAs"]`), getTeamValue(pd, team))
+		}
+		if !startTime.IsZero() {
+			form.MustElement(`input[name="startDay"]`).MustSelectAllText().MustInput(startTime.Format("2006-01-02"))
+			form.MustElement(`input[name="startTime"]`).MustSelectAllText().MustInput(startTime.Format("15:04"))
+		}
+		form.MustElement(`input.submit`).MustClick().WaitInvisible()
+	}); err != nil {
+		return VirtualState{}, err
+	}
+
+	// Redirected to the dashboard on success.
+	if _, err := p.Race().Element(`.error`).Handle(handleErrMsg).
+		Element(`.problems`).Do(); err != nil {
+		return VirtualState{}, err
+	}
+
+	return getVirtualState(p.parse())
+}
+
+// GetVirtualState returns the state of virtual participation.
+// See Args.GetVirtualState for more details.
+func (c *Client) GetVirtualState(arg Args) (VirtualState, error) {
+	return c.GetVirtualStateContext(context.Background(), arg)
+}
+
+// GetVirtualStateContext is the same as GetVirtualState, using the given context.
+func (c *Client) GetVirtualStateContext(ctx context.Context, arg Args) (VirtualState, error) {
+	if arg.Class == ClassGroup {
+		return VirtualState{}, ErrInvalidSpecifier
+	}
+
+	link, err := c.DashboardPage(arg)
+	if err != nil {
+		return VirtualState{}, err
+	}
+
+	pd, err := c.fetch(ctx, link, `#footer`)
+	if err != nil {
+		return VirtualState{}, err
+	}
+
+	return getVirtualState(pd)
+}
@@ -0,0 +1,365 @@
+package codeforces
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// webClient fetches pages of the website over plain HTTP, and
+// parses them as served, without running any scripts. Used to
+// serve read-only data, if the source is SourceHTTP.
+type webClient struct {
+	client *http.Client
+	host   string
+}
+
+func newWebClient(host string, jar http.CookieJar) *webClient {
+	if jar == nil {
+		jar, _ = cookiejar.New(nil)
+	}
+	return &webClient{client: &http.Client{Jar: jar}, host: host}
+}
+
+// setCookies replaces the cookies (logged in session) of the
+// website held by the client with the given cookies.
+func (w *webClient) setCookies(cookies []*http.Cookie) {
+	u, err := url.Parse(w.host)
+	if err != nil {
+		return
+	}
+
+	var expired []*http.Cookie
+	for _, cookie := range w.client.Jar.Cookies(u) {
+		expired = append(expired, &http.Cookie{Name: cookie.Name, MaxAge: -1})
+	}
+	w.client.Jar.SetCookies(u, expired)
+	w.client.Jar.SetCookies(u, cookies)
+}
+
+// get fetches the page at link, and returns it parsed.
+func (w *webClient) get(ctx context.Context, link string) (*goquery.Document, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return nil, err
+	}
+	return w.do(req, link)
+}
+
+// post submits the given form (in the page at link), with the
+// given fields changed, and returns the resulting page parsed.
+// All other fields (like the csrf token) are sent as is, same
+// as the browser does; unchecked checkboxes (and radio buttons)
+// are left out.
+func (w *webClient) post(ctx context.Context, link string, form *goquery.Selection, values map[string]string) (*goquery.Document, error) {
+	data := url.Values{}
+	form.Find(`input[name]`).Each(func(_ int, input *goquery.Selection) {
+		switch input.AttrOr("type", "") {
+		case "submit":
+			return
+		case "checkbox", "radio":
+			if _, ok := input.Attr("checked"); !ok {
+				return
+			}
+			data.Set(input.AttrOr("name", ""), input.AttrOr("value", "on"))
+		default:
+			data.Set(input.AttrOr("name", ""), input.AttrOr("value", ""))
+		}
+	})
+	form.Find(`select[name]`).Each(func(_ int, sel *goquery.Selection) {
+		option := sel.Find(`option[selected]`)
+		if option.Length() == 0 {
+			option = sel.Find(`option`)
+		}
+		data.Set(sel.AttrOr("name", ""), option.First().AttrOr("value", ""))
+	})
+	for name, value := range values {
+		data.Set(name, value)
+	}
+
+	action, err := url.Parse(link)
+	if err != nil {
+		return nil, err
+	}
+	if action, err = action.Parse(form.AttrOr("action", "")); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, action.String(), strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return w.do(req, link)
+}
+
+// do sends the request, and returns the page parsed. If the
+// page was redirected from link, the error notification (if
+// any) is returned instead, same as page.waitFor does. Error
+// responses are returned as the notification in the page, or
+// else the error corresponding to the status code.
+func (w *webClient) do(req *http.Request, link string) (*goquery.Document, error) {
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	pd, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil && resp.StatusCode == http.StatusOK {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if pd != nil {
+			if err := getErrMsg(pd); err != nil {
+				return nil, err
+			}
+		}
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			return nil, &SiteError{Err: ErrNotLoggedIn, Msg: resp.Status}
+		case http.StatusForbidden:
+			return nil, &SiteError{Err: ErrAccessDenied, Msg: resp.Status}
+		case http.StatusNotFound:
+			return nil, &SiteError{Err: ErrContestNotFound, Msg: resp.Status}
+		}
+		return nil, &SiteError{Err: ErrRequestFailed, Msg: fmt.Sprintf("%v: %v", link, resp.Status)}
+	}
+
+	if resp.Request.URL.String() != link {
+		// An unexpected redirect occurred.
+		// Return error notification, if any.
+		if err := getErrMsg(pd); err != nil {
+			return nil, err
+		}
+		return nil, ErrRedirected
+	}
+	return pd, nil
+}
+
+// nextPage returns the link to the next page of the paginated
+// table in the page. Returns empty if it is the last page.
+func (w *webClient) nextPage(pd *goquery.Document) string {
+	href := ""
+	pd.Find(`.pagination li>a`).EachWithBreak(func(_ int, a *goquery.Selection) bool {
+		if strings.Contains(a.Text(), "→") {
+			href = a.AttrOr("href", "")
+			return false
+		}
+		return true
+	})
+
+	if href == "" {
+		return ""
+	}
+	if strings.HasPrefix(href, "/") {
+		href = w.host + href
+	}
+	return href
+}
+
+func (w *webClient) pageContests(ctx context.Context, arg Args, link string, pageCount uint) (<-chan []Contest, error) {
+	pd, err := w.get(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+
+	chanContests := make(chan []Contest)
+	go func() {
+		defer close(chanContests)
+
+		for ; pageCount > 0; pageCount-- {
+			// Ignore error, write whatever is parsed.
+			contests, _ := getContests(pd, arg)
+			select {
+			case chanContests <- contests:
+			case <-ctx.Done():
+				return
+			}
+
+			link := w.nextPage(pd)
+			if link == "" || pageCount == 1 {
+				// All pages parsed.
+				return
+			}
+
+			if pd, err = w.get(ctx, link); err != nil {
+				return
+			}
+
+			// Remove upcoming contests table.
+			if arg.Class == ClassContest {
+				pd.Find(`.contestList .datatable`).First().Remove()
+			}
+		}
+	}()
+
+	return chanContests, nil
+}
+
+// pageStandings parses the standings, where the link to each
+// page (indexed from 1) of the standings is given by pageLink.
+func (w *webClient) pageStandings(ctx context.Context, pageCount uint, pageLink func(page int) (string, error)) (<-chan []StandingsRow, error) {
+	link, err := pageLink(1)
+	if err != nil {
+		return nil, err
+	}
+
+	pd, err := w.get(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+
+	chanStandings := make(chan []StandingsRow)
+	go func() {
+		defer close(chanStandings)
+
+		for page := 1; uint(page) <= pageCount; page++ {
+			// Ignore error, write whatever is parsed.
+			rows, _ := getStandings(pd)
+			select {
+			case chanStandings <- rows:
+			case <-ctx.Done():
+				return
+			}
+
+			if page >= getStandingsPageCount(pd) {
+				// All pages parsed.
+				return
+			}
+
+			link, _ := pageLink(page + 1)
+			if pd, err = w.get(ctx, link); err != nil {
+				return
+			}
+		}
+	}()
+
+	return chanStandings, nil
+}
+
+func (w *webClient) pageProblemset(ctx context.Context, link string, pageCount uint, filter ProblemsetFilter) (<-chan []Problem, error) {
+	pd, err := w.get(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+
+	chanProblems := make(chan []Problem)
+	go func() {
+		defer close(chanProblems)
+
+		for ; pageCount > 0; pageCount-- {
+			// Ignore error, write whatever is parsed.
+			problems, _ := getProblemset(pd)
+			select {
+			case chanProblems <- filter.filter(problems):
+			case <-ctx.Done():
+				return
+			}
+
+			link := w.nextPage(pd)
+			if link == "" || pageCount == 1 {
+				// All pages parsed.
+				return
+			}
+
+			if pd, err = w.get(ctx, link); err != nil {
+				return
+			}
+		}
+	}()
+
+	return chanProblems, nil
+}
+
+// pageSubmissions parses submissions in the page at link (see
+// Client.submissions), where submissions not made in the period
+// [start, end) of the contest are considered practice.
+func (w *webClient) pageSubmissions(ctx context.Context, arg Args, link string, pageCount uint, opts SubmissionsOptions, start, end time.Time) (<-chan []Submission, error) {
+	pd, err := w.get(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply the filters on the website, where possible. Needed
+	// even without filters, as the website remembers the last
+	// filters set (in any other session).
+	if values := getStatusFilter(pd, arg, opts); values != nil {
+		if pd, err = w.post(ctx, link, pd.Find(`form.status-filter`), values); err != nil {
+			return nil, err
+		}
+	}
+
+	// parse returns the filtered submissions in the page,
+	// and if older submissions (in later pages) are of use.
+	parse := func() ([]Submission, bool) {
+		// Ignore error, write whatever is parsed.
+		submissions, _ := getSubmissions(pd, arg)
+		more := len(submissions) != 0 && (opts.Since.IsZero() ||
+			!submissions[len(submissions)-1].When.Before(opts.Since))
+		return opts.filter(submissions, arg, start, end), more
+	}
+
+	chanSubmissions := make(chan []Submission)
+	go func() {
+		defer close(chanSubmissions)
+
+		// Only one page to parse. Keep parsing till all verdicts are declared.
+		if pageCount == 1 {
+			for {
+				submissions, _ := parse()
+				select {
+				case chanSubmissions <- submissions:
+				case <-ctx.Done():
+					return
+				}
+
+				isJudging := false
+				for _, sub := range submissions {
+					isJudging = isJudging || sub.IsJudging
+				}
+				if !isJudging {
+					return
+				}
+
+				// Wait for atleast 1.5 seconds before parsing again.
+				select {
+				case <-time.After(time.Millisecond * 1500):
+				case <-ctx.Done():
+					return
+				}
+				if pd, err = w.get(ctx, link); err != nil {
+					return
+				}
+			}
+		}
+
+		for ; pageCount > 0; pageCount-- {
+			submissions, more := parse()
+			select {
+			case chanSubmissions <- submissions:
+			case <-ctx.Done():
+				return
+			}
+
+			link := w.nextPage(pd)
+			if !more || link == "" || pageCount == 1 {
+				// All pages (of use) parsed.
+				return
+			}
+
+			if pd, err = w.get(ctx, link); err != nil {
+				return
+			}
+		}
+	}()
+
+	return chanSubmissions, nil
+}
@@ -0,0 +1,400 @@
+package codeforces
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// newMockWeb returns a local server standing in for the website,
+// serving saved pages (in the 'testdata' directory) at the paths
+// given by routes. Forms posted to any route redirect back to it;
+// the values posted are sent to the returned channel.
+func newMockWeb(t *testing.T, routes map[string]string) (*httptest.Server, <-chan url.Values) {
+	t.Helper()
+
+	chanForms := make(chan url.Values, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fixture, ok := routes[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		if r.Method == http.MethodPost {
+			r.ParseForm()
+			chanForms <- r.PostForm
+			http.Redirect(w, r, r.URL.Path, http.StatusFound)
+			return
+		}
+
+		// Route to a fixture is a redirect.
+		if fixture[0] == '/' {
+			http.Redirect(w, r, fixture, http.StatusFound)
+			return
+		}
+		http.ServeFile(w, r, filepath.Join("testdata", fixture))
+	}))
+
+	t.Cleanup(srv.Close)
+	return srv, chanForms
+}
+
+// newWebTestClient returns a client using the mock website
+// (over plain HTTP) as source.
+func newWebTestClient(t *testing.T, srv *httptest.Server) *Client {
+	t.Helper()
+
+	c, err := NewClient(Options{Source: SourceHTTP, HostURL: srv.URL})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return c
+}
+
+func Test_webClient_get(t *testing.T) {
+	srv, _ := newMockWeb(t, map[string]string{
+		"/contest/4/countdown": "countdown.html",
+		"/error":               "error.html",
+		"/contest/999":         "/error",
+		"/contest/5":           "/contest/4/countdown",
+	})
+	w := newWebClient(srv.URL, nil)
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr error
+	}{
+		{
+			name:    "Test #1",
+			path:    "/contest/4/countdown",
+			wantErr: nil,
+		},
+		{
+			name:    "Test #2", // Redirected with notification.
+			path:    "/contest/999",
+			wantErr: ErrContestNotFound,
+		},
+		{
+			name:    "Test #3", // Redirected without notification.
+			path:    "/contest/5",
+			wantErr: ErrRedirected,
+		},
+		{
+			name:    "Test #4", // Page not found.
+			path:    "/contest/6",
+			wantErr: ErrContestNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := w.get(context.Background(), srv.URL+tt.path)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("webClient.get() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_webClient_post(t *testing.T) {
+	srv, chanForms := newMockWeb(t, map[string]string{
+		"/form": "countdown.html",
+	})
+	w := newWebClient(srv.URL, nil)
+
+	pd, _ := goquery.NewDocumentFromReader(strings.NewReader(`<form method="post">
+		<input type="hidden" name="csrf_token" value="abc">
+		<input type="checkbox" name="remember">
+		<input type="checkbox" name="agree" checked>
+		<input type="radio" name="mode" value="a">
+		<input type="radio" name="mode" value="b" checked>
+		<input type="submit" name="submit" value="Submit">
+	</form>`))
+	if _, err := w.post(context.Background(), srv.URL+"/form", pd.Find(`form`), map[string]string{"handle": "cp-tools"}); err != nil {
+		t.Fatalf("webClient.post() error = %v", err)
+	}
+
+	want := url.Values{
+		"csrf_token": {"abc"},
+		"agree":      {"on"},
+		"mode":       {"b"},
+		"handle":     {"cp-tools"},
+	}
+	if got := <-chanForms; !reflect.DeepEqual(got, want) {
+		t.Errorf("webClient.post() form = %v, want %v", got, want)
+	}
+}
+
+func Test_webClient_setCookies(t *testing.T) {
+	w := newWebClient("https://codeforces.com", nil)
+	u, _ := url.Parse("https://codeforces.com")
+	w.client.Jar.SetCookies(u, []*http.Cookie{{Name: "X-User", Value: "old"}, {Name: "JSESSIONID", Value: "old"}})
+
+	w.setCookies([]*http.Cookie{{Name: "JSESSIONID", Value: "new"}})
+	got := w.client.Jar.Cookies(u)
+	if len(got) != 1 || got[0].Name != "JSESSIONID" || got[0].Value != "new" {
+		t.Errorf("webClient.setCookies() cookies = %v, want only JSESSIONID=new", got)
+	}
+}
+
+func TestClient_GetCountdown_http(t *testing.T) {
+	srv, _ := newMockWeb(t, map[string]string{
+		"/contest/4/countdown": "countdown.html",
+	})
+	c := newWebTestClient(t, srv)
+	defer c.Close()
+
+	got, err := c.GetCountdown(Args{"4", "", "contest", ""})
+	if err != nil {
+		t.Fatalf("Client.GetCountdown() error = %v", err)
+	}
+	want, _ := getCountdown(loadFixture(t, "countdown.html"))
+	if got != want {
+		t.Errorf("Client.GetCountdown() = %v, want %v", got, want)
+	}
+}
+
+func TestClient_GetContests_http(t *testing.T) {
+	srv, _ := newMockWeb(t, map[string]string{
+		"/contests":        "contests_list.html",
+		"/contests/page/2": "contests_list.html",
+	})
+	c := newWebTestClient(t, srv)
+	defer c.Close()
+
+	chanContests, err := c.GetContests(Args{"", "", "contest", ""}, 2)
+	if err != nil {
+		t.Fatalf("Client.GetContests() error = %v", err)
+	}
+
+	var pages [][]Contest
+	for contests := range chanContests {
+		pages = append(pages, contests)
+	}
+
+	want, _ := getContests(loadFixture(t, "contests_list.html"), Args{"", "", "contest", ""})
+	if len(pages) != 2 || !reflect.DeepEqual(pages[0], want) {
+		t.Fatalf("Client.GetContests() = %v, want %v", pages, want)
+	}
+	// Upcoming contests are listed only in the first page.
+	if len(pages[1]) >= len(pages[0]) {
+		t.Errorf("Client.GetContests() = %v, want upcoming contests removed", pages[1])
+	}
+}
+
+func TestClient_GetSubmissions_http(t *testing.T) {
+	srv, chanForms := newMockWeb(t, map[string]string{
+		"/contest/4/my": "submissions.html",
+	})
+	c := newWebTestClient(t, srv)
+	defer c.Close()
+
+	arg := Args{"4", "", "contest", ""}
+	opts := SubmissionsOptions{Verdicts: []int{VerdictCE}}
+	chanSubmissions, err := c.GetSubmissionsWithOptions(arg, "", 2, opts)
+	if err != nil {
+		t.Fatalf("Client.GetSubmissionsWithOptions() error = %v", err)
+	}
+
+	// Filters are applied on the website.
+	select {
+	case form := <-chanForms:
+		want := url.Values{
+			"action":                {"setupSubmissionFilter"},
+			"frameProblemIndex":     {"anyProblem"},
+			"verdictName":           {"COMPILATION_ERROR"},
+			"programTypeForInvoker": {"anyProgramTypeForInvoker"},
+		}
+		if !reflect.DeepEqual(form, want) {
+			t.Errorf("Client.GetSubmissionsWithOptions() form = %v, want %v", form, want)
+		}
+	default:
+		t.Errorf("Client.GetSubmissionsWithOptions() form not posted")
+	}
+
+	// Filters are applied locally too.
+	all, _ := getSubmissions(loadFixture(t, "submissions.html"), arg)
+	want := opts.filter(all, arg, time.Time{}, time.Time{})
+	if got := <-chanSubmissions; !reflect.DeepEqual(got, want) {
+		t.Errorf("Client.GetSubmissionsWithOptions() = %v, want %v", got, want)
+	}
+
+	// Filters set by the last call (the page still shows problem
+	// 'A' selected) are reset by an unfiltered call.
+	chanSubmissions, err = c.GetSubmissions(arg, "", 2)
+	if err != nil {
+		t.Fatalf("Client.GetSubmissions() error = %v", err)
+	}
+	for range chanSubmissions {
+	}
+	select {
+	case form := <-chanForms:
+		want := url.Values{
+			"action":                {"setupSubmissionFilter"},
+			"frameProblemIndex":     {"anyProblem"},
+			"verdictName":           {"anyVerdict"},
+			"programTypeForInvoker": {"anyProgramTypeForInvoker"},
+		}
+		if !reflect.DeepEqual(form, want) {
+			t.Errorf("Client.GetSubmissions() form = %v, want %v", form, want)
+		}
+	default:
+		t.Errorf("Client.GetSubmissions() form not posted")
+	}
+}
+
+func TestClient_GetProblemset_http(t *testing.T) {
+	srv, _ := newMockWeb(t, map[string]string{
+		"/problemset":        "problemset.html",
+		"/problemset/page/2": "problemset.html",
+	})
+	c := newWebTestClient(t, srv)
+	defer c.Close()
+
+	chanProblems, err := c.GetProblemset(ProblemsetFilter{HideSolved: true}, 3)
+	if err != nil {
+		t.Fatalf("Client.GetProblemset() error = %v", err)
+	}
+
+	var got []string
+	for problems := range chanProblems {
+		for _, problem := range problems {
+			got = append(got, problem.Name)
+		}
+	}
+
+	// The fixture links to a next page always; pageCount limits the pages parsed.
+	want := []string{"Mysterious Present", "Cheat and Win", "Mysterious Present", "Cheat and Win", "Mysterious Present", "Cheat and Win"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Client.GetProblemset() = %v, want %v", got, want)
+	}
+}
+
+func TestClient_GetDashboard_http(t *testing.T) {
+	srv, _ := newMockWeb(t, map[string]string{
+		"/contest/4":             "dashboard_contest.html",
+		"/api/contest.standings": "api/contest.standings.json",
+		"/contest/5":             "dashboard_running.html",
+	})
+	c := newWebTestClient(t, srv)
+	defer c.Close()
+
+	dashboard, err := c.GetDashboard(Args{"4", "", "contest", ""})
+	if err != nil {
+		t.Fatalf("Client.GetDashboard() error = %v", err)
+	}
+
+	// Problems not returned by the API are left without tags.
+	want := map[string]Problem{
+		"a": {Tags: []string{"brute force", "math"}, Rating: 800},
+		"b": {Tags: []string{"constructive algorithms", "greedy"}, Rating: 1200},
+	}
+	if len(dashboard.Problem) == 0 {
+		t.Fatalf("Client.GetDashboard() = %v, want problems", dashboard)
+	}
+	for _, problem := range dashboard.Problem {
+		w := want[problem.Arg.Problem]
+		if !reflect.DeepEqual(problem.Tags, w.Tags) || problem.Rating != w.Rating {
+			t.Errorf("Client.GetDashboard() problem = %v, want tags %v and rating %v", problem, w.Tags, w.Rating)
+		}
+	}
+
+	// Tags (hidden) are not fetched while the contest is running.
+	dashboard, err = c.GetDashboard(Args{"5", "", "contest", ""})
+	if err != nil {
+		t.Fatalf("Client.GetDashboard() error = %v", err)
+	}
+	if dashboard.Countdown == 0 || len(dashboard.Problem) == 0 {
+		t.Fatalf("Client.GetDashboard() = %v, want running contest", dashboard)
+	}
+	for _, problem := range dashboard.Problem {
+		if problem.Tags != nil || problem.Rating != 0 {
+			t.Errorf("Client.GetDashboard() problem = %v, want no tags", problem)
+		}
+	}
+
+}
+
+func TestClient_GetDashboard_http_apiFailed(t *testing.T) {
+	defer func(timeout time.Duration) { dashboardTagsTimeout = timeout }(dashboardTagsTimeout)
+	dashboardTagsTimeout = time.Millisecond * 100
+
+	tests := []struct {
+		name string
+		api  http.HandlerFunc
+	}{
+		{
+			name: "Test #1", // Call limit exceeded; not retried.
+			api: func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"status":"FAILED","comment":"Call limit exceeded"}`)
+			},
+		},
+		{
+			name: "Test #2", // API doesn't respond.
+			api: func(w http.ResponseWriter, r *http.Request) {
+				<-r.Context().Done()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/api/contest.standings" {
+					calls++
+					tt.api(w, r)
+					return
+				}
+				http.ServeFile(w, r, filepath.Join("testdata", "dashboard_contest.html"))
+			}))
+			defer srv.Close()
+
+			c := newWebTestClient(t, srv)
+			defer c.Close()
+
+			// Tags are optional; the dashboard is returned without them.
+			start := time.Now()
+			dashboard, err := c.GetDashboard(Args{"4", "", "contest", ""})
+			if err != nil || len(dashboard.Problem) == 0 || dashboard.Problem[0].Tags != nil {
+				t.Errorf("Client.GetDashboard() = %v, %v, want problems without tags", dashboard, err)
+			}
+			if calls != 1 || time.Since(start) > time.Second {
+				t.Errorf("Client.GetDashboard() took %v and %v calls, want a single short call", time.Since(start), calls)
+			}
+		})
+	}
+}
+
+func TestClient_GetUser_http(t *testing.T) {
+	srv, _ := newMockWeb(t, map[string]string{
+		"/profile/tourist":     "profile.html",
+		"/contest/4/countdown": "countdown.html",
+		// Unknown handles redirect away from the profile.
+		"/profile/nobody": "/contest/4/countdown",
+	})
+	c := newWebTestClient(t, srv)
+	defer c.Close()
+
+	got, err := c.GetUser("tourist")
+	if err != nil {
+		t.Fatalf("Client.GetUser() error = %v", err)
+	}
+	if want, _ := getUser(loadFixture(t, "profile.html")); !reflect.DeepEqual(got, want) {
+		t.Errorf("Client.GetUser() = %v, want %v", got, want)
+	}
+
+	if _, err := c.GetUser("nobody"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Client.GetUser() error = %v, want %v", err, ErrUserNotFound)
+	}
+}
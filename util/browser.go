@@ -11,6 +11,14 @@ import (
 
 // NewBrowser initiates the automated browser to use.
 func NewBrowser(headless bool, userDataDir, bin, cacheDir string) (*rod.Browser, error) {
+	return NewBrowserWithProfile(headless, userDataDir, bin, cacheDir, "")
+}
+
+// NewBrowserWithProfile is the same as NewBrowser, only the browser
+// data is stored in the named profile (directory) in cacheDir, which
+// persists across launches. Browsers launched alongside must use
+// different profiles. An empty profile is the same as NewBrowser.
+func NewBrowserWithProfile(headless bool, userDataDir, bin, cacheDir, profile string) (*rod.Browser, error) {
 	// Launch browser.
 	launchBrowser := func(controlURL string) (*rod.Browser, error) {
 		b := rod.New().ControlURL(controlURL).NoDefaultDevice()
@@ -22,6 +30,9 @@ func NewBrowser(headless bool, userDataDir, bin, cacheDir string) (*rod.Browser,
 
 	// Store data in cache (to reduce time).
 	cacheUserDataDir := filepath.Join(cacheDir, bin)
+	if profile != "" {
+		cacheUserDataDir = filepath.Join(cacheDir, "profiles", profile)
+	}
 
 	// Initiate the browser to use.
 	l := launcher.New().
@@ -66,7 +77,17 @@ func NewBrowser(headless bool, userDataDir, bin, cacheDir string) (*rod.Browser,
 
 // NewPage loads the given link in a new browser tab.
 func NewPage(browser *rod.Browser, link string, block []proto.NetworkResourceType) (*rod.Page, error) {
-	page, err := browser.Page(proto.TargetCreateTarget{URL: link})
+	return NewPageWithCassette(browser, link, block, nil)
+}
+
+// NewPageWithCassette is the same as NewPage, only all network
+// responses are recorded to (or replayed from) the given cassette.
+// A nil cassette is the same as calling NewPage.
+func NewPageWithCassette(browser *rod.Browser, link string, block []proto.NetworkResourceType,
+	cassette *Cassette) (*rod.Page, error) {
+
+	// Hijack requests before the page is loaded.
+	page, err := browser.Page(proto.TargetCreateTarget{})
 	if err != nil {
 		return nil, err
 	}
@@ -79,9 +100,24 @@ func NewPage(browser *rod.Browser, link string, block []proto.NetworkResourceTyp
 				return
 			}
 		}
-		h.ContinueRequest(&proto.FetchContinueRequest{})
+
+		switch {
+		case cassette == nil:
+			h.ContinueRequest(&proto.FetchContinueRequest{})
+		case cassette.Mode == CassetteRecord:
+			cassette.record(page, h)
+		case cassette.Mode == CassetteReplay:
+			cassette.replay(h)
+		default:
+			h.ContinueRequest(&proto.FetchContinueRequest{})
+		}
 	})
 	go router.Run()
 
+	if err := page.Navigate(link); err != nil {
+		page.Close()
+		return nil, err
+	}
+
 	return page, nil
 }
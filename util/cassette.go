@@ -0,0 +1,189 @@
+package util
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// Modes of a cassette.
+const (
+	// CassetteRecord saves all network responses to the cassette.
+	CassetteRecord = iota + 1
+	// CassetteReplay serves requests only from the cassette.
+	CassetteReplay
+)
+
+type (
+	// Cassette holds network responses of browser pages, saved
+	// to a directory. In record mode, every response received
+	// is saved to the cassette; in replay mode, requests are
+	// served only from the cassette, without any network access.
+	//
+	// A cassette is safe for concurrent use by multiple pages.
+	Cassette struct {
+		Dir  string
+		Mode int
+
+		mutex sync.Mutex
+		// Number of times each request has been recorded/replayed.
+		count map[string]int
+	}
+
+	// Track is a single network response saved in the cassette.
+	Track struct {
+		URL    string      `json:"url"`
+		Method string      `json:"method"`
+		Status int         `json:"status"`
+		Header http.Header `json:"header"`
+		Body   []byte      `json:"body"`
+	}
+)
+
+// NewCassette returns a cassette, saved to the given directory,
+// to use in the given mode. The directory is created (if not present)
+// when recording, and must exist when replaying.
+func NewCassette(dir string, mode int) (*Cassette, error) {
+	switch mode {
+	case CassetteRecord:
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, err
+		}
+
+	case CassetteReplay:
+		if file, err := os.Stat(dir); err != nil || !file.IsDir() {
+			return nil, fmt.Errorf("cassette directory not found")
+		}
+
+	default:
+		return nil, fmt.Errorf("invalid cassette mode")
+	}
+
+	c := &Cassette{
+		Dir:   dir,
+		Mode:  mode,
+		count: make(map[string]int),
+	}
+	return c, nil
+}
+
+// key returns the file name prefix of the request.
+func (c *Cassette) key(method, link string) string {
+	hash := sha1.Sum([]byte(method + " " + link))
+	return hex.EncodeToString(hash[:])
+}
+
+// Save adds the track to the cassette. Tracks of the same request
+// are saved in order, so that they can be replayed in order.
+func (c *Cassette) Save(t Track) error {
+	c.mutex.Lock()
+	key := c.key(t.Method, t.URL)
+	index := c.count[key]
+	c.count[key]++
+	c.mutex.Unlock()
+
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	file := filepath.Join(c.Dir, fmt.Sprintf("%v-%v.json", key, index))
+	return ioutil.WriteFile(file, data, 0644)
+}
+
+// Load returns the next track of the request from the cassette.
+// Once all tracks of the request are replayed, the last track
+// is returned on every subsequent call.
+func (c *Cassette) Load(method, link string) (Track, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	key := c.key(method, link)
+	index := c.count[key]
+
+	data, err := ioutil.ReadFile(filepath.Join(c.Dir, fmt.Sprintf("%v-%v.json", key, index)))
+	if os.IsNotExist(err) && index > 0 {
+		// Replay the last track again.
+		index--
+		data, err = ioutil.ReadFile(filepath.Join(c.Dir, fmt.Sprintf("%v-%v.json", key, index)))
+	}
+	if err != nil {
+		return Track{}, fmt.Errorf("request not found in cassette")
+	}
+	c.count[key] = index + 1
+
+	var t Track
+	err = json.Unmarshal(data, &t)
+	return t, err
+}
+
+// record sends the hijacked request to the remote server,
+// saving the response received to the cassette.
+func (c *Cassette) record(page *rod.Page, h *rod.Hijack) {
+	req := h.Request.Req()
+
+	// Requests hijacked don't include cookies of the browser.
+	if cookies, err := (proto.NetworkGetCookies{Urls: []string{req.URL.String()}}).Call(page); err == nil {
+		for _, cookie := range cookies.Cookies {
+			req.AddCookie(&http.Cookie{Name: cookie.Name, Value: cookie.Value})
+		}
+	}
+	// Let the client handle (and decode) the encoding.
+	for name := range req.Header {
+		if strings.EqualFold(name, "Accept-Encoding") {
+			delete(req.Header, name)
+		}
+	}
+
+	client := &http.Client{
+		// Redirects are to be followed by the browser.
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	if err := h.LoadResponse(client, true); err != nil {
+		h.Response.Fail(proto.NetworkErrorReasonFailed)
+		return
+	}
+
+	header := h.Response.Headers()
+	header.Del("Content-Encoding")
+	header.Del("Content-Length")
+
+	// Failing to save the track doesn't affect the page.
+	c.Save(Track{
+		URL:    req.URL.String(),
+		Method: req.Method,
+		Status: h.Response.Payload().ResponseCode,
+		Header: header,
+		Body:   h.Response.Payload().Body,
+	})
+}
+
+// replay serves the hijacked request from the cassette.
+func (c *Cassette) replay(h *rod.Hijack) {
+	t, err := c.Load(h.Request.Method(), h.Request.URL().String())
+	if err != nil {
+		h.Response.Fail(proto.NetworkErrorReasonInternetDisconnected)
+		return
+	}
+
+	h.Response.Payload().ResponseCode = t.Status
+	for name, values := range t.Header {
+		for _, value := range values {
+			h.Response.SetHeader(name, value)
+		}
+	}
+	h.Response.SetBody(t.Body)
+}
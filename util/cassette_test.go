@@ -0,0 +1,105 @@
+package util
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestNewCassette(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cpt-cassette")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name    string
+		dir     string
+		mode    int
+		wantErr bool
+	}{
+		{
+			name:    "Test #1",
+			dir:     dir + "/record",
+			mode:    CassetteRecord,
+			wantErr: false,
+		},
+		{
+			name:    "Test #2",
+			dir:     dir + "/record",
+			mode:    CassetteReplay,
+			wantErr: false,
+		},
+		{
+			name:    "Test #3", // Nothing to replay.
+			dir:     dir + "/invalid",
+			mode:    CassetteReplay,
+			wantErr: true,
+		},
+		{
+			name:    "Test #4", // Invalid mode.
+			dir:     dir,
+			mode:    0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewCassette(tt.dir, tt.mode); (err != nil) != tt.wantErr {
+				t.Errorf("NewCassette() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCassette_SaveLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cpt-cassette")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	link := "https://codeforces.com/contest/4/my"
+	tracks := []Track{
+		{
+			URL:    link,
+			Method: "GET",
+			Status: 200,
+			Header: http.Header{"Content-Type": {"text/html"}},
+			Body:   []byte("In queue"),
+		},
+		{
+			URL:    link,
+			Method: "GET",
+			Status: 200,
+			Header: http.Header{"Content-Type": {"text/html"}},
+			Body:   []byte("Accepted"),
+		},
+	}
+
+	recorder, _ := NewCassette(dir, CassetteRecord)
+	for _, track := range tracks {
+		if err := recorder.Save(track); err != nil {
+			t.Fatalf("Cassette.Save() error = %v", err)
+		}
+	}
+
+	player, _ := NewCassette(dir, CassetteReplay)
+	// Tracks are replayed in order, the last one repeated.
+	for _, want := range append(tracks, tracks[1]) {
+		got, err := player.Load("GET", link)
+		if err != nil {
+			t.Fatalf("Cassette.Load() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Cassette.Load() = %v, want %v", got, want)
+		}
+	}
+
+	if _, err := player.Load("POST", link); err == nil {
+		t.Errorf("Cassette.Load() expected error for request not recorded")
+	}
+}